@@ -4,13 +4,17 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
+	"time"
 )
 
 func NewConfig() (*ConfigModel, error) {
 	cfg := &ConfigModel{
 		HTTP: HTTPConfig{
-			Host: env("HTTP_HOST", "0.0.0.0"),
-			Port: env("HTTP_PORT", "8080"),
+			Host:            env("HTTP_HOST", "0.0.0.0"),
+			Port:            env("HTTP_PORT", "8080"),
+			ShutdownDelay:   envDuration("HTTP_SHUTDOWN_DELAY", 0),
+			ShutdownTimeout: envDuration("HTTP_SHUTDOWN_TIMEOUT", 10*time.Second),
 		},
 		Postgres: PostgresConfig{
 			Host:     env("POSTGRES_HOST", "pr-db"),
@@ -21,11 +25,73 @@ func NewConfig() (*ConfigModel, error) {
 			SSLMode:  env("POSTGRES_SSLMODE", "disable"),
 			PgDriver: env("POSTGRES_DRIVER", "pgx"),
 		},
+		Admin: AdminConfig{
+			Token: env("ADMIN_TOKEN", ""),
+		},
+		Webhooks: WebhooksConfig{
+			DispatchInterval: envDuration("WEBHOOK_DISPATCH_INTERVAL", time.Second),
+			BatchSize:        envInt("WEBHOOK_BATCH_SIZE", 50),
+			MaxAttempts:      envInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			BackoffBase:      envDuration("WEBHOOK_BACKOFF_BASE", 2*time.Second),
+			BackoffMax:       envDuration("WEBHOOK_BACKOFF_MAX", 10*time.Minute),
+			Timeout:          envDuration("WEBHOOK_TIMEOUT", 5*time.Second),
+		},
+		Integrations: IntegrationsConfig{
+			GitHubWebhookSecret: env("GITHUB_WEBHOOK_SECRET", ""),
+			GitLabWebhookToken:  env("GITLAB_WEBHOOK_TOKEN", ""),
+		},
+		Availability: AvailabilityConfig{
+			CheckInterval: envDuration("AVAILABILITY_CHECK_INTERVAL", time.Minute),
+		},
+		SLA: SLAConfig{
+			CheckInterval:  envDuration("SLA_CHECK_INTERVAL", time.Minute),
+			ReminderURL:    env("SLA_REMINDER_URL", ""),
+			ReminderSecret: env("SLA_REMINDER_SECRET", ""),
+			Timeout:        envDuration("SLA_REMINDER_TIMEOUT", 5*time.Second),
+		},
+		Tracing: TracingConfig{
+			Exporter:    env("OTEL_TRACES_EXPORTER", "none"),
+			ServiceName: env("OTEL_SERVICE_NAME", "pr-service"),
+			SampleRatio: envFloat("OTEL_TRACES_SAMPLER_ARG", 1),
+		},
+		Auth: AuthConfig{
+			Enabled:     envBool("AUTH_ENABLED", false),
+			JWTSecret:   env("AUTH_JWT_SECRET", ""),
+			JWKSFile:    env("AUTH_JWKS_FILE", ""),
+			JWTIssuer:   env("AUTH_JWT_ISSUER", ""),
+			JWTAudience: env("AUTH_JWT_AUDIENCE", ""),
+			RBACEnabled: envBool("RBAC_ENABLED", false),
+
+			TrustUserHeader: envBool("AUTH_TRUST_USER_HEADER", false),
+		},
 	}
 
 	if cfg.HTTP.Host == "" || cfg.HTTP.Port == "" {
 		return nil, fmt.Errorf("HTTP_HOST and HTTP_PORT must be set")
 	}
+	if cfg.HTTP.ShutdownTimeout <= 0 || cfg.HTTP.ShutdownDelay < 0 {
+		return nil, fmt.Errorf("HTTP_SHUTDOWN_TIMEOUT must be positive and HTTP_SHUTDOWN_DELAY not negative")
+	}
+	if cfg.Webhooks.BatchSize <= 0 || cfg.Webhooks.MaxAttempts <= 0 {
+		return nil, fmt.Errorf("WEBHOOK_BATCH_SIZE and WEBHOOK_MAX_ATTEMPTS must be positive")
+	}
+	if cfg.Webhooks.DispatchInterval <= 0 || cfg.Availability.CheckInterval <= 0 || cfg.SLA.CheckInterval <= 0 {
+		return nil, fmt.Errorf("WEBHOOK_DISPATCH_INTERVAL, AVAILABILITY_CHECK_INTERVAL and SLA_CHECK_INTERVAL must be positive")
+	}
+	switch cfg.Tracing.Exporter {
+	case "none", "stdout", "otlp":
+	default:
+		return nil, fmt.Errorf("OTEL_TRACES_EXPORTER must be one of otlp, stdout, none")
+	}
+	if cfg.Tracing.SampleRatio < 0 || cfg.Tracing.SampleRatio > 1 {
+		return nil, fmt.Errorf("OTEL_TRACES_SAMPLER_ARG must be between 0 and 1")
+	}
+	if cfg.Auth.Enabled && cfg.Auth.JWTSecret == "" && cfg.Auth.JWKSFile == "" && cfg.Admin.Token == "" {
+		return nil, fmt.Errorf("AUTH_ENABLED requires AUTH_JWT_SECRET, AUTH_JWKS_FILE or ADMIN_TOKEN to issue API keys")
+	}
+	if cfg.Auth.RBACEnabled && !cfg.Auth.Enabled && !cfg.Auth.TrustUserHeader {
+		return nil, fmt.Errorf("RBAC_ENABLED requires AUTH_ENABLED or AUTH_TRUST_USER_HEADER behind a gateway that sets X-User-ID")
+	}
 	if cfg.Postgres.Host == "" || cfg.Postgres.User == "" || cfg.Postgres.DBName == "" {
 		return nil, fmt.Errorf("POSTGRES_HOST, POSTGRES_USER and POSTGRES_DB must be set")
 	}
@@ -40,3 +106,55 @@ func env(key, def string) string {
 	}
 	return def
 }
+
+func envInt(key string, def int) int {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		slog.Warn("invalid integer in environment, using default", "key", key, "value", v)
+		return def
+	}
+	return n
+}
+
+func envDuration(key string, def time.Duration) time.Duration {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		slog.Warn("invalid duration in environment, using default", "key", key, "value", v)
+		return def
+	}
+	return d
+}
+
+func envFloat(key string, def float64) float64 {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		slog.Warn("invalid number in environment, using default", "key", key, "value", v)
+		return def
+	}
+	return f
+}
+
+func envBool(key string, def bool) bool {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		slog.Warn("invalid boolean in environment, using default", "key", key, "value", v)
+		return def
+	}
+	return b
+}
@@ -1,8 +1,17 @@
 package config
 
+import "time"
+
 type ConfigModel struct {
-	HTTP     HTTPConfig
-	Postgres PostgresConfig
+	HTTP         HTTPConfig
+	Postgres     PostgresConfig
+	Admin        AdminConfig
+	Webhooks     WebhooksConfig
+	Integrations IntegrationsConfig
+	Availability AvailabilityConfig
+	SLA          SLAConfig
+	Tracing      TracingConfig
+	Auth         AuthConfig
 }
 
 type PostgresConfig struct {
@@ -18,4 +27,56 @@ type PostgresConfig struct {
 type HTTPConfig struct {
 	Host string
 	Port string
+	// ShutdownDelay is how long /readyz fails before the listener closes.
+	ShutdownDelay time.Duration
+	// ShutdownTimeout bounds how long in-flight requests are drained on stop.
+	ShutdownTimeout time.Duration
+}
+
+type AdminConfig struct {
+	Token string
+}
+
+type WebhooksConfig struct {
+	DispatchInterval time.Duration
+	BatchSize        int
+	MaxAttempts      int
+	BackoffBase      time.Duration
+	BackoffMax       time.Duration
+	Timeout          time.Duration
+}
+
+type IntegrationsConfig struct {
+	GitHubWebhookSecret string
+	GitLabWebhookToken  string
+}
+
+type AvailabilityConfig struct {
+	CheckInterval time.Duration
+}
+
+type SLAConfig struct {
+	CheckInterval  time.Duration
+	ReminderURL    string
+	ReminderSecret string
+	Timeout        time.Duration
+}
+
+type TracingConfig struct {
+	Exporter    string
+	ServiceName string
+	SampleRatio float64
+}
+
+type AuthConfig struct {
+	Enabled     bool
+	JWTSecret   string
+	JWKSFile    string
+	JWTIssuer   string
+	JWTAudience string
+	// RBACEnabled makes the usecase layer enforce caller roles.
+	RBACEnabled bool
+	// TrustUserHeader takes X-User-ID as the caller when authentication is
+	// off. Only safe behind a gateway that sets the header itself.
+	TrustUserHeader bool
 }
@@ -4,8 +4,12 @@ import (
 	"context"
 	"pr-service/config"
 	"pr-service/internal/domain/delivery/http"
+	"pr-service/internal/domain/health"
+	"pr-service/internal/domain/metrics"
 	"pr-service/internal/domain/repository"
+	"pr-service/internal/domain/tracing"
 	"pr-service/internal/domain/usecase"
+	"pr-service/internal/domain/worker"
 
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
@@ -15,8 +19,12 @@ import (
 func New() *fx.App {
 	return fx.New(
 		fx.Options(
+			tracing.New(),
 			repository.New(),
+			metrics.New(),
 			usecase.New(),
+			worker.New(),
+			health.New(),
 			http.New(),
 		),
 		fx.Provide(
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// APIKeyPrefix marks API keys so they can be told apart from JWTs when both
+// arrive as bearer tokens.
+const APIKeyPrefix = "prs_"
+
+// displayPrefixLen characters of a key are kept in clear to identify it in
+// listings.
+const displayPrefixLen = len(APIKeyPrefix) + 6
+
+// GenerateAPIKey returns a new random key, its displayable prefix and the hash
+// to store. The key itself is never persisted.
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", "", err
+	}
+	key = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return key, key[:displayPrefixLen], HashAPIKey(key), nil
+}
+
+// HashAPIKey uses plain SHA-256: keys carry 256 bits of entropy, so a slow
+// password hash would add latency to every request without adding safety.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func IsAPIKey(credential string) bool {
+	return strings.HasPrefix(credential, APIKeyPrefix)
+}
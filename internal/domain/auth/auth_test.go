@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"pr-service/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func b64(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func writeJWKS(t *testing.T, keys ...map[string]string) string {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	if err != nil {
+		t.Fatalf("marshal JWKS: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write JWKS: %v", err)
+	}
+	return path
+}
+
+func claims(sub string, ttl time.Duration) jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Subject:   sub,
+		Issuer:    "https://idp.example",
+		Audience:  jwt.ClaimStrings{"pr-service"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+	}
+}
+
+func TestJWTVerifierHMAC(t *testing.T) {
+	v, err := NewJWTVerifier(&config.ConfigModel{Auth: config.AuthConfig{
+		JWTSecret:   "s3cret",
+		JWTIssuer:   "https://idp.example",
+		JWTAudience: "pr-service",
+	}})
+	if err != nil {
+		t.Fatalf("NewJWTVerifier: %v", err)
+	}
+
+	sign := func(c jwt.Claims, secret string) string {
+		s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte(secret))
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		return s
+	}
+
+	got, err := v.Verify(sign(claims("alice", time.Hour), "s3cret"))
+	if err != nil || got.Subject != "alice" || got.Tenant != "" {
+		t.Fatalf("Verify(valid) = %+v, %v", got, err)
+	}
+
+	withTenant := tokenClaims{RegisteredClaims: claims("alice", time.Hour), Tenant: "acme"}
+	if got, err := v.Verify(sign(withTenant, "s3cret")); err != nil || got.Tenant != "acme" {
+		t.Fatalf("Verify(tenant) = %+v, %v", got, err)
+	}
+
+	wrongIssuer := claims("alice", time.Hour)
+	wrongIssuer.Issuer = "https://evil.example"
+	noExpiry := claims("alice", time.Hour)
+	noExpiry.ExpiresAt = nil
+
+	for name, token := range map[string]string{
+		"wrong secret": sign(claims("alice", time.Hour), "other"),
+		"expired":      sign(claims("alice", -time.Hour), "s3cret"),
+		"wrong issuer": sign(wrongIssuer, "s3cret"),
+		"no expiry":    sign(noExpiry, "s3cret"),
+		"no subject":   sign(claims("", time.Hour), "s3cret"),
+		"garbage":      "not.a.jwt",
+	} {
+		if _, err := v.Verify(token); err == nil {
+			t.Errorf("%s: expected rejection", name)
+		}
+	}
+}
+
+func TestJWTVerifierJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate EC key: %v", err)
+	}
+
+	path := writeJWKS(t,
+		map[string]string{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": b64(rsaKey.N), "e": b64(big.NewInt(int64(rsaKey.E)))},
+		map[string]string{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": b64(ecKey.X), "y": b64(ecKey.Y)},
+		map[string]string{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": b64(rsaKey.N), "e": "AQAB"},
+	)
+
+	v, err := NewJWTVerifier(&config.ConfigModel{Auth: config.AuthConfig{JWKSFile: path}})
+	if err != nil {
+		t.Fatalf("NewJWTVerifier: %v", err)
+	}
+
+	sign := func(method jwt.SigningMethod, kid string, key any) string {
+		tok := jwt.NewWithClaims(method, claims("bob", time.Hour))
+		tok.Header["kid"] = kid
+		s, err := tok.SignedString(key)
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		return s
+	}
+
+	if got, err := v.Verify(sign(jwt.SigningMethodRS256, "rsa-1", rsaKey)); err != nil || got.Subject != "bob" {
+		t.Fatalf("Verify(RS256) = %+v, %v", got, err)
+	}
+	if got, err := v.Verify(sign(jwt.SigningMethodES256, "ec-1", ecKey)); err != nil || got.Subject != "bob" {
+		t.Fatalf("Verify(ES256) = %+v, %v", got, err)
+	}
+	if _, err := v.Verify(sign(jwt.SigningMethodRS256, "enc-1", rsaKey)); err == nil {
+		t.Fatalf("encryption keys must not verify signatures")
+	}
+	if _, err := v.Verify(sign(jwt.SigningMethodRS256, "ec-1", rsaKey)); err == nil {
+		t.Fatalf("expected rejection for mismatched key")
+	}
+
+	// HMAC tokens must not be accepted when only a JWKS is configured.
+	hs, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims("bob", time.Hour)).SignedString([]byte("x"))
+	if _, err := v.Verify(hs); err == nil {
+		t.Fatalf("expected HS256 to be rejected without a secret")
+	}
+}
+
+func TestJWTVerifierDisabled(t *testing.T) {
+	v, err := NewJWTVerifier(&config.ConfigModel{})
+	if err != nil {
+		t.Fatalf("NewJWTVerifier: %v", err)
+	}
+	if v.Enabled() {
+		t.Fatalf("verifier without secret or JWKS must be disabled")
+	}
+	if _, err := v.Verify("anything"); err != ErrJWTDisabled {
+		t.Fatalf("Verify = %v, want ErrJWTDisabled", err)
+	}
+}
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, prefix, hash, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("GenerateAPIKey: %v", err)
+	}
+	if !IsAPIKey(key) || !strings.HasPrefix(key, prefix) || len(prefix) != displayPrefixLen {
+		t.Fatalf("unexpected key %q / prefix %q", key, prefix)
+	}
+	if hash != HashAPIKey(key) || strings.Contains(hash, key) {
+		t.Fatalf("hash must be derived from, and not contain, the key")
+	}
+
+	other, _, _, _ := GenerateAPIKey()
+	if other == key {
+		t.Fatalf("keys must be random")
+	}
+}
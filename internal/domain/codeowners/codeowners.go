@@ -0,0 +1,123 @@
+package codeowners
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type Rule struct {
+	Pattern string
+	Owners  []string
+	re      *regexp.Regexp
+}
+
+type Ruleset struct {
+	rules []Rule
+}
+
+// Parse reads a CODEOWNERS file. Owners are returned without the leading "@".
+func Parse(content string) (Ruleset, error) {
+	var rs Ruleset
+	for i, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		pattern := fields[0]
+		if strings.HasPrefix(pattern, `\#`) {
+			pattern = pattern[1:]
+		}
+		re, err := compile(pattern)
+		if err != nil {
+			return Ruleset{}, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		owners := make([]string, 0, len(fields)-1)
+		for _, f := range fields[1:] {
+			if strings.HasPrefix(f, "#") {
+				break
+			}
+			owners = append(owners, strings.TrimPrefix(f, "@"))
+		}
+		rs.rules = append(rs.rules, Rule{Pattern: pattern, Owners: owners, re: re})
+	}
+	return rs, nil
+}
+
+func (rs Ruleset) Len() int {
+	return len(rs.rules)
+}
+
+// Owners returns the owners of path according to the last matching rule.
+// A matching rule without owners leaves the path unowned.
+func (rs Ruleset) Owners(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(rs.rules) - 1; i >= 0; i-- {
+		if rs.rules[i].re.MatchString(path) {
+			return rs.rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// compile translates a CODEOWNERS pattern into a regexp. It follows the
+// gitignore rules GitHub uses: a pattern with a leading or inner "/" is
+// anchored to the repository root, otherwise it matches at any depth; "*"
+// and "?" stop at "/", "**" crosses directories. A pattern that names a
+// directory owns everything below it, but "dir/*" covers direct children
+// only.
+func compile(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") {
+		return nil, fmt.Errorf("negated pattern %q is not supported", pattern)
+	}
+	if strings.ContainsAny(pattern, "[]") {
+		return nil, fmt.Errorf("character ranges in %q are not supported", pattern)
+	}
+
+	dirOnly := strings.HasSuffix(pattern, "/")
+	p := strings.Trim(pattern, "/")
+	if p == "" {
+		return nil, fmt.Errorf("empty pattern %q", pattern)
+	}
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(p, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(p); {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 3
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i += 2
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+			i++
+		case p[i] == '?':
+			b.WriteString("[^/]")
+			i++
+		default:
+			b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+			i++
+		}
+	}
+
+	last := p[strings.LastIndex(p, "/")+1:]
+	switch {
+	case dirOnly:
+		b.WriteString("/.*$")
+	case strings.ContainsAny(last, "*?"):
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(b.String())
+}
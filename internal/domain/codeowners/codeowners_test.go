@@ -0,0 +1,101 @@
+package codeowners
+
+import (
+	"slices"
+	"testing"
+)
+
+const sample = `# Global owners, overridden below.
+*       @global-owner
+
+# JavaScript anywhere.
+*.js    @js-owner #inline comment
+
+/build/ @doctocat
+docs/*  docs@example.com
+apps/   @octocat
+/scripts/ @doctocat @octocat
+**/logs @monalisa
+
+# No owners: ownership of this path is removed.
+/apps/github
+`
+
+func TestOwnersLastMatchWins(t *testing.T) {
+	rs, err := Parse(sample)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if rs.Len() != 8 {
+		t.Fatalf("expected 8 rules, got %d", rs.Len())
+	}
+
+	cases := []struct {
+		path   string
+		owners []string
+	}{
+		{"README.md", []string{"global-owner"}},
+		{"web/app.js", []string{"js-owner"}},
+		{"build/out.txt", []string{"doctocat"}},
+		{"docs/getting-started.md", []string{"docs@example.com"}},
+		{"docs/build-app/troubleshooting.md", []string{"global-owner"}},
+		{"apps/web/main.go", []string{"octocat"}},
+		{"services/apps/main.go", []string{"octocat"}},
+		{"scripts/deploy.sh", []string{"doctocat", "octocat"}},
+		{"/scripts/deploy.js", []string{"doctocat", "octocat"}},
+		{"deep/nested/logs/a.log", []string{"monalisa"}},
+		{"build/logs/a.log", []string{"monalisa"}},
+		{"apps/github/main.go", nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			got := rs.Owners(tc.path)
+			if len(got) == 0 && len(tc.owners) == 0 {
+				return
+			}
+			if !slices.Equal(got, tc.owners) {
+				t.Fatalf("Owners(%q) = %v, want %v", tc.path, got, tc.owners)
+			}
+		})
+	}
+}
+
+func TestPatternSemantics(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"*", "a/b/c.txt", true},
+		{"*.go", "cmd/main.go", true},
+		{"*.go", "cmd/main.gox", false},
+		{"/docs/", "docs/a.md", true},
+		{"/docs/", "src/docs/a.md", false},
+		{"docs/", "src/docs/a.md", true},
+		{"/build/logs", "build/logs/x/y.log", true},
+		{"src/**/test?.go", "src/a/b/test1.go", true},
+		{"src/**/test?.go", "src/test1.go", true},
+		{"src/**/test?.go", "src/a/test12.go", false},
+		{"docs/**", "docs/a/b/c.md", true},
+		{"internal/*.sql", "internal/db/x.sql", false},
+	}
+
+	for _, tc := range cases {
+		re, err := compile(tc.pattern)
+		if err != nil {
+			t.Fatalf("compile(%q): %v", tc.pattern, err)
+		}
+		if got := re.MatchString(tc.path); got != tc.match {
+			t.Errorf("pattern %q on %q: got %v, want %v", tc.pattern, tc.path, got, tc.match)
+		}
+	}
+}
+
+func TestParseRejectsUnsupportedSyntax(t *testing.T) {
+	for _, content := range []string{"!docs/ @a", "[a-z].go @a", "* @a\n/ @b"} {
+		if _, err := Parse(content); err == nil {
+			t.Errorf("expected error for %q", content)
+		}
+	}
+}
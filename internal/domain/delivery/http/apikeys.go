@@ -0,0 +1,54 @@
+package http
+
+import (
+	"net/http"
+	"pr-service/internal/domain/entities"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) HandleAPIKeyCreate(c *gin.Context) {
+	var req entities.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	key, err := s.Usecase.CreateAPIKey(c.Request.Context(), req)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"api_key": key,
+	})
+}
+
+func (s *Server) HandleAPIKeyList(c *gin.Context) {
+	keys, err := s.Usecase.ListAPIKeys(c.Request.Context())
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"api_keys": keys,
+	})
+}
+
+func (s *Server) HandleAPIKeyRevoke(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := s.Usecase.RevokeAPIKey(c.Request.Context(), id); err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
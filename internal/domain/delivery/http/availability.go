@@ -0,0 +1,79 @@
+package http
+
+import (
+	"net/http"
+	"pr-service/internal/domain/entities"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) HandleAvailabilityCreate(c *gin.Context) {
+	var req entities.CreateUnavailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	ua, err := s.Usecase.CreateUnavailability(c.Request.Context(), req)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"unavailability": ua,
+	})
+}
+
+func (s *Server) HandleAvailabilityUpdate(c *gin.Context) {
+	var req entities.UpdateUnavailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	ua, err := s.Usecase.UpdateUnavailability(c.Request.Context(), req)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"unavailability": ua,
+	})
+}
+
+func (s *Server) HandleAvailabilityList(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	periods, err := s.Usecase.ListUnavailability(c.Request.Context(), userID)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id":        userID,
+		"unavailability": periods,
+	})
+}
+
+func (s *Server) HandleAvailabilityDelete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := s.Usecase.DeleteUnavailability(c.Request.Context(), id); err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"pr-service/internal/domain/entities"
+	"pr-service/internal/domain/integrations/github"
+	"pr-service/internal/domain/integrations/gitlab"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const maxWebhookBodySize = 5 << 20
+
+func (s *Server) HandleGitHubWebhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodySize))
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	signature := c.GetHeader(github.SignatureHeader)
+	tenantID, ok, err := s.webhookTenant(c, entities.IdentityProviderGitHub, s.cfg.Integrations.GitHubWebhookSecret,
+		func(secret string) bool {
+			return github.VerifySignature([]byte(secret), body, signature)
+		})
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+	if !ok {
+		s.logger.Warn("github webhook signature matches no tenant")
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	switch c.GetHeader(github.EventHeader) {
+	case github.EventPing:
+		c.Status(http.StatusOK)
+		return
+	case github.EventPullRequest:
+	default:
+		c.JSON(http.StatusAccepted, entities.ExternalEventResult{Ignored: true})
+		return
+	}
+
+	ev, err := github.ParsePullRequestEvent(body)
+	if err != nil {
+		s.logger.Warn("invalid github pull_request payload", zap.Error(err))
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	ext, ok := ev.ToExternal()
+	if !ok {
+		c.JSON(http.StatusAccepted, entities.ExternalEventResult{Action: ev.Action, Ignored: true})
+		return
+	}
+
+	// The signature check above is what authenticates the sender.
+	res, err := s.Usecase.ApplyExternalPullRequestEvent(webhookContext(c, tenantID), ext)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *Server) HandleGitLabWebhook(c *gin.Context) {
+	token := c.GetHeader(gitlab.TokenHeader)
+	tenantID, ok, err := s.webhookTenant(c, entities.IdentityProviderGitLab, s.cfg.Integrations.GitLabWebhookToken,
+		func(secret string) bool {
+			return gitlab.VerifyToken(secret, token)
+		})
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+	if !ok {
+		s.logger.Warn("gitlab webhook token matches no tenant")
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	if c.GetHeader(gitlab.EventHeader) != gitlab.EventMergeRequest {
+		c.JSON(http.StatusAccepted, entities.ExternalEventResult{Ignored: true})
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodySize))
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	ev, err := gitlab.ParseMergeRequestEvent(body)
+	if err != nil {
+		s.logger.Warn("invalid gitlab merge request payload", zap.Error(err))
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	ext, ok := ev.ToExternal()
+	if !ok {
+		c.JSON(http.StatusAccepted, entities.ExternalEventResult{Action: ev.ObjectAttributes.Action, Ignored: true})
+		return
+	}
+
+	res, err := s.Usecase.ApplyExternalPullRequestEvent(webhookContext(c, tenantID), ext)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// webhookTenant finds the tenant whose secret verifies the webhook. The
+// secret from the environment belongs to the default tenant. The request
+// itself never names the tenant: whoever holds one tenant's secret must not
+// reach another.
+func (s *Server) webhookTenant(c *gin.Context, provider, envSecret string, verify func(secret string) bool) (string, bool, error) {
+	if envSecret != "" && verify(envSecret) {
+		return entities.DefaultTenant, true, nil
+	}
+
+	secrets, err := s.Usecase.WebhookSecrets(c.Request.Context(), provider)
+	if err != nil {
+		return "", false, err
+	}
+	for _, secret := range secrets {
+		if verify(secret.Secret) {
+			return secret.TenantID, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// webhookContext acts as the system in the tenant the webhook was signed for.
+func webhookContext(c *gin.Context, tenantID string) context.Context {
+	p := entities.SystemPrincipal
+	p.TenantID = tenantID
+	ctx := entities.WithTenant(c.Request.Context(), tenantID)
+	return entities.WithPrincipal(ctx, p)
+}
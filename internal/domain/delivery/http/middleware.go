@@ -0,0 +1,146 @@
+package http
+
+import (
+	"net/http"
+	"pr-service/internal/domain/entities"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	actorHeader    = "X-Actor"
+	anonymousActor = "anonymous"
+)
+
+func (s *Server) actorMiddleware(c *gin.Context) {
+	actor := c.GetHeader(actorHeader)
+	if actor == "" {
+		actor = anonymousActor
+	}
+	c.Request = c.Request.WithContext(entities.WithActor(c.Request.Context(), actor))
+	c.Next()
+}
+
+// unmatchedRoute labels requests that hit no route, so scanners probing
+// random paths cannot blow up metric cardinality.
+const unmatchedRoute = "unmatched"
+
+func (s *Server) metricsMiddleware(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	route := c.FullPath()
+	if route == "" {
+		route = unmatchedRoute
+	}
+	s.metrics.ObserveHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+}
+
+// traceRequest keeps scrapes and probes out of traces.
+func traceRequest(r *http.Request) bool {
+	switch r.URL.Path {
+	case "/health", "/livez", "/readyz", "/metrics":
+		return false
+	}
+	return true
+}
+
+const (
+	apiKeyHeader = "X-API-Key"
+	userHeader   = "X-User-ID"
+	tenantHeader = "X-Tenant-ID"
+)
+
+var adminTokenPrincipal = entities.Principal{
+	Subject: "admin",
+	Method:  entities.AuthMethodAdminToken,
+	Role:    entities.RoleAdmin,
+}
+
+// authMiddleware identifies the caller: X-Admin-Token, then an API key
+// (X-API-Key or bearer) or a JWT bearer token when AUTH_ENABLED is set. With
+// authentication off and AUTH_TRUST_USER_HEADER set, X-User-ID is trusted as
+// set by a gateway in front of the service; other requests pass anonymously.
+//
+// Authenticated callers work in the tenant of their credential. The admin
+// token and unauthenticated requests pick one with X-Tenant-ID.
+//
+// With authentication on the actor recorded in history is always the
+// principal; X-Actor is only honored when there is nothing to check it against.
+func (s *Server) authMiddleware(c *gin.Context) {
+	ctx := c.Request.Context()
+	tenantID := c.GetHeader(tenantHeader)
+	if tenantID == "" {
+		tenantID = entities.DefaultTenant
+	}
+
+	var (
+		principal entities.Principal
+		err       error
+	)
+	switch {
+	case s.hasAdminToken(c):
+		principal = adminTokenPrincipal
+		principal.TenantID = tenantID
+		err = s.Usecase.CheckTenant(ctx, tenantID)
+	case s.cfg.Auth.Enabled:
+		principal, err = s.Usecase.Authenticate(ctx, bearerCredential(c))
+	case s.cfg.Auth.TrustUserHeader && c.GetHeader(userHeader) != "":
+		if err = s.Usecase.CheckTenant(ctx, tenantID); err == nil {
+			principal, err = s.Usecase.IdentifyUser(entities.WithTenant(ctx, tenantID), c.GetHeader(userHeader))
+		}
+	default:
+		if err := s.Usecase.CheckTenant(ctx, tenantID); err != nil {
+			s.handleError(c, err)
+			c.Abort()
+			return
+		}
+		c.Request = c.Request.WithContext(entities.WithTenant(ctx, tenantID))
+		c.Next()
+		return
+	}
+	if err != nil {
+		c.Header("WWW-Authenticate", "Bearer")
+		s.handleError(c, err)
+		c.Abort()
+		return
+	}
+
+	ctx = entities.WithTenant(ctx, principal.TenantID)
+	ctx = entities.WithPrincipal(ctx, principal)
+	if s.cfg.Auth.Enabled || c.GetHeader(actorHeader) == "" {
+		ctx = entities.WithActor(ctx, principal.Subject)
+	}
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+}
+
+func bearerCredential(c *gin.Context) string {
+	if key := c.GetHeader(apiKeyHeader); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
+
+func (s *Server) adminMiddleware(c *gin.Context) {
+	if !s.isAdmin(c) {
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+	c.Next()
+}
+
+// adminTokenMiddleware guards operations that span tenants; a tenant's own
+// admins cannot reach them.
+func (s *Server) adminTokenMiddleware(c *gin.Context) {
+	if !s.hasAdminToken(c) {
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+	c.Next()
+}
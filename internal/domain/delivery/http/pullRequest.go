@@ -32,7 +32,12 @@ func (s *Server) HandlePullRequestMerge(c *gin.Context) {
 		return
 	}
 
-	pr, err := s.Usecase.MergePullRequest(c.Request.Context(), req.PullRequestID)
+	if req.Force && !s.isAdmin(c) {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	pr, err := s.Usecase.MergePullRequest(c.Request.Context(), req.PullRequestID, req.Force)
 	if err != nil {
 		s.handleError(c, err)
 		return
@@ -65,3 +70,109 @@ func (s *Server) HandlePullRequestReassign(c *gin.Context) {
 		"replaced_by": replacedBy,
 	})
 }
+
+func (s *Server) HandlePullRequestReview(c *gin.Context) {
+	var req entities.SubmitReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	pr, err := s.Usecase.SubmitReview(c.Request.Context(), req)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pr": pr,
+	})
+}
+
+func (s *Server) HandlePullRequestApprove(c *gin.Context) {
+	var req entities.ApprovePullRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	pr, err := s.Usecase.ApprovePullRequest(c.Request.Context(), req.PullRequestID, req.ReviewerID)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pr": pr,
+	})
+}
+
+func (s *Server) HandlePullRequestClose(c *gin.Context) {
+	var req entities.PullRequestStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	pr, err := s.Usecase.ClosePullRequest(c.Request.Context(), req.PullRequestID)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pr": pr,
+	})
+}
+
+func (s *Server) HandlePullRequestReopen(c *gin.Context) {
+	var req entities.PullRequestStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	pr, err := s.Usecase.ReopenPullRequest(c.Request.Context(), req.PullRequestID)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pr": pr,
+	})
+}
+
+func (s *Server) HandlePullRequestReady(c *gin.Context) {
+	var req entities.PullRequestStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	pr, err := s.Usecase.MarkPullRequestReady(c.Request.Context(), req.PullRequestID)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pr": pr,
+	})
+}
+
+func (s *Server) HandlePullRequestHistory(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+	if prID == "" {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.Usecase.GetPullRequestHistory(c.Request.Context(), prID)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
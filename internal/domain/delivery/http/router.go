@@ -1,18 +1,67 @@
 package http
 
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
 func (s *Server) createController() {
+	s.serv.Use(
+		otelgin.Middleware(s.cfg.Tracing.ServiceName, otelgin.WithFilter(traceRequest)),
+		s.metricsMiddleware,
+		s.actorMiddleware,
+	)
+
 	s.serv.GET("/health", s.Health)
+	s.serv.GET("/livez", s.Livez)
+	s.serv.GET("/readyz", s.Readyz)
+	s.serv.GET("/metrics", s.adminTokenMiddleware, gin.WrapH(s.metrics.Handler()))
+
+	s.serv.POST("/integrations/github/webhook", s.HandleGitHubWebhook)
+	s.serv.POST("/integrations/gitlab/webhook", s.HandleGitLabWebhook)
+
+	api := s.serv.Group("/", s.authMiddleware)
+
+	admin := api.Group("/admin", s.adminMiddleware)
+	admin.POST("/apiKeys", s.HandleAPIKeyCreate)
+	admin.GET("/apiKeys", s.HandleAPIKeyList)
+	admin.DELETE("/apiKeys", s.HandleAPIKeyRevoke)
+	admin.POST("/tenants", s.adminTokenMiddleware, s.HandleTenantCreate)
+	admin.GET("/tenants", s.adminTokenMiddleware, s.HandleTenantList)
+	admin.POST("/tenants/webhookSecret", s.adminTokenMiddleware, s.HandleWebhookSecretRotate)
+
+	api.POST("/team/add", s.HandleTeamAdd)
+	api.GET("/team/get", s.HandleTeamGet)
+	api.POST("/team/bulkDeactivate", s.HandleTeamBulkDeactivate)
+	api.GET("/team/settings", s.HandleTeamSettingsGet)
+	api.POST("/team/settings", s.HandleTeamSettingsUpdate)
+	api.GET("/team/codeowners", s.HandleTeamCodeownersGet)
+	api.POST("/team/codeowners", s.HandleTeamCodeownersUpload)
 
-	s.serv.POST("/team/add", s.HandleTeamAdd)
-	s.serv.GET("/team/get", s.HandleTeamGet)
-	s.serv.POST("/team/bulkDeactivate", s.HandleTeamBulkDeactivate)
+	api.POST("/users/setIsActive", s.HandleSetIsActive)
+	api.GET("/users/getReview", s.HandleGetUserReview)
+	api.POST("/users/setCapacity", s.HandleSetCapacity)
+	api.POST("/users/setRole", s.adminMiddleware, s.HandleSetRole)
+	api.POST("/users/linkIdentity", s.adminMiddleware, s.HandleLinkIdentity)
+	api.POST("/users/availability", s.HandleAvailabilityCreate)
+	api.PUT("/users/availability", s.HandleAvailabilityUpdate)
+	api.GET("/users/availability", s.HandleAvailabilityList)
+	api.DELETE("/users/availability", s.HandleAvailabilityDelete)
 
-	s.serv.POST("/users/setIsActive", s.HandleSetIsActive)
-	s.serv.GET("/users/getReview", s.HandleGetUserReview)
+	api.POST("/pullRequest/create", s.HandlePullRequestCreate)
+	api.POST("/pullRequest/merge", s.HandlePullRequestMerge)
+	api.POST("/pullRequest/reassign", s.HandlePullRequestReassign)
+	api.POST("/pullRequest/review", s.HandlePullRequestReview)
+	api.POST("/pullRequest/approve", s.HandlePullRequestApprove)
+	api.POST("/pullRequest/close", s.HandlePullRequestClose)
+	api.POST("/pullRequest/reopen", s.HandlePullRequestReopen)
+	api.POST("/pullRequest/ready", s.HandlePullRequestReady)
+	api.GET("/pullRequest/history", s.HandlePullRequestHistory)
 
-	s.serv.POST("/pullRequest/create", s.HandlePullRequestCreate)
-	s.serv.POST("/pullRequest/merge", s.HandlePullRequestMerge)
-	s.serv.POST("/pullRequest/reassign", s.HandlePullRequestReassign)
+	api.GET("/stats/assignments", s.HandleAssignmentsStats)
+	api.GET("/stats/sla", s.HandleSLAStats)
 
-	s.serv.GET("/stats/assignments", s.HandleAssignmentsStats)
+	api.POST("/webhooks", s.HandleWebhookCreate)
+	api.GET("/webhooks", s.HandleWebhookList)
+	api.DELETE("/webhooks", s.HandleWebhookDelete)
 }
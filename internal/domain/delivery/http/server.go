@@ -2,44 +2,106 @@ package http
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"pr-service/config"
 	"pr-service/internal/domain/entities"
+	"pr-service/internal/domain/health"
+	"pr-service/internal/domain/metrics"
 	"pr-service/internal/domain/usecase"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
 
 type Server struct {
-	logger  *zap.Logger
-	cfg     *config.ConfigModel
-	serv    *gin.Engine
-	Usecase *usecase.Usecase
+	logger   *zap.Logger
+	cfg      *config.ConfigModel
+	serv     *gin.Engine
+	srv      *http.Server
+	metrics  *metrics.Metrics
+	health   *health.Checker
+	shutdown fx.Shutdowner
+	Usecase  *usecase.Usecase
 }
 
-func NewServer(logger *zap.Logger, cfg *config.ConfigModel, uc *usecase.Usecase) (*Server, error) {
+func NewServer(
+	logger *zap.Logger,
+	cfg *config.ConfigModel,
+	uc *usecase.Usecase,
+	m *metrics.Metrics,
+	hc *health.Checker,
+	shutdown fx.Shutdowner,
+) (*Server, error) {
 	return &Server{
-		logger:  logger,
-		cfg:     cfg,
-		serv:    gin.Default(),
-		Usecase: uc,
+		logger:   logger,
+		cfg:      cfg,
+		serv:     gin.Default(),
+		metrics:  m,
+		health:   hc,
+		shutdown: shutdown,
+		Usecase:  uc,
 	}, nil
 }
 
+// readHeaderTimeout keeps slow clients from holding connections open.
+const readHeaderTimeout = 10 * time.Second
+
+// OnStart binds the port before returning, so a port conflict fails startup.
 func (s *Server) OnStart(_ context.Context) error {
 	s.createController()
+
+	addr := net.JoinHostPort(s.cfg.HTTP.Host, s.cfg.HTTP.Port)
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", addr, err)
+	}
+
+	s.srv = &http.Server{
+		Handler:           s.serv.Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
 	go func() {
-		addr := s.cfg.HTTP.Host + ":" + s.cfg.HTTP.Port
-		s.logger.Info("HTTP server started", zap.String("addr", addr))
-		if err := s.serv.Run(addr); err != nil {
+		s.logger.Info("HTTP server started", zap.String("addr", ln.Addr().String()))
+		if err := s.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			s.logger.Error("failed to serve", zap.Error(err))
+			_ = s.shutdown.Shutdown(fx.ExitCode(1))
 		}
 	}()
 	return nil
 }
 
-func (s *Server) OnStop(_ context.Context) error {
+// OnStop fails readiness for HTTP_SHUTDOWN_DELAY so load balancers stop
+// routing here, then stops accepting connections and waits for in-flight
+// requests up to HTTP_SHUTDOWN_TIMEOUT; whatever is still running after that
+// is cut off.
+func (s *Server) OnStop(ctx context.Context) error {
+	if s.srv == nil {
+		return nil
+	}
+
+	s.health.Drain()
+	if delay := s.cfg.HTTP.ShutdownDelay; delay > 0 {
+		s.logger.Info("http server draining", zap.Duration("delay", delay))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+
+	s.logger.Info("http server shutting down")
+	if err := s.srv.Shutdown(ctx); err != nil {
+		_ = s.srv.Close()
+		return fmt.Errorf("shutdown http server: %w", err)
+	}
 	s.logger.Info("http server stopped")
 	return nil
 }
@@ -64,12 +126,20 @@ func (s *Server) writeDomainError(c *gin.Context, derr *entities.DomainError) {
 	switch derr.Code {
 	case entities.ErrorCodeTeamExists:
 		status = http.StatusBadRequest
-	case entities.ErrorCodePRExists:
+	case entities.ErrorCodePRExists, entities.ErrorCodeTenantExists, entities.ErrorCodeIdentityLinked:
 		status = http.StatusConflict
 	case entities.ErrorCodePRMerged, entities.ErrorCodeNotAssigned, entities.ErrorCodeNoCandidate:
 		status = http.StatusConflict
+	case entities.ErrorCodeNotApproved, entities.ErrorCodeInvalidStatus:
+		status = http.StatusConflict
 	case entities.ErrorCodeNotFound:
 		status = http.StatusNotFound
+	case entities.ErrorCodeUnknownIdentity:
+		status = http.StatusUnprocessableEntity
+	case entities.ErrorCodeUnauthorized:
+		status = http.StatusUnauthorized
+	case entities.ErrorCodeForbidden:
+		status = http.StatusForbidden
 	}
 
 	c.JSON(status, entities.ErrorResponse{
@@ -80,6 +150,36 @@ func (s *Server) writeDomainError(c *gin.Context, derr *entities.DomainError) {
 	})
 }
 
+func (s *Server) isAdmin(c *gin.Context) bool {
+	if p, ok := entities.PrincipalFromContext(c.Request.Context()); ok && p.Role == entities.RoleAdmin {
+		return true
+	}
+	return s.hasAdminToken(c)
+}
+
+func (s *Server) hasAdminToken(c *gin.Context) bool {
+	token := c.GetHeader("X-Admin-Token")
+	if s.cfg.Admin.Token == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.cfg.Admin.Token)) == 1
+}
+
 func (s *Server) Health(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
+
+// Livez only tells that the process serves requests; dependencies are
+// checked by Readyz.
+func (s *Server) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": entities.CheckStatusOK})
+}
+
+func (s *Server) Readyz(c *gin.Context) {
+	report := s.health.Ready(c.Request.Context())
+	status := http.StatusOK
+	if report.Status != entities.CheckStatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
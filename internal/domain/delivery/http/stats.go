@@ -2,12 +2,35 @@ package http
 
 import (
 	"net/http"
+	"pr-service/internal/domain/entities"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 func (s *Server) HandleAssignmentsStats(c *gin.Context) {
-	resp, err := s.Usecase.GetAssignmentsStats(c.Request.Context())
+	filter := entities.AssignmentsStatsFilter{
+		TeamName: c.Query("team_name"),
+		Status:   c.Query("status"),
+	}
+	switch filter.Status {
+	case "", entities.PullRequestStatusOpen, entities.PullRequestStatusMerged:
+	default:
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	if filter.From, err = parseTimeQuery(c, "from"); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	if filter.To, err = parseTimeQuery(c, "to"); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.Usecase.GetAssignmentsStats(c.Request.Context(), filter)
 	if err != nil {
 		s.handleError(c, err)
 		return
@@ -15,3 +38,34 @@ func (s *Server) HandleAssignmentsStats(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+func (s *Server) HandleSLAStats(c *gin.Context) {
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	to, err := parseTimeQuery(c, "to")
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	resp, err := s.Usecase.GetSLAStats(c.Request.Context(), from, to, c.Query("team_name"))
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// parseTimeQuery reads an optional RFC 3339 timestamp; a missing parameter
+// yields the zero time.
+func parseTimeQuery(c *gin.Context, key string) (time.Time, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
@@ -62,3 +62,73 @@ func (s *Server) HandleTeamBulkDeactivate(c *gin.Context) {
 		"result": result,
 	})
 }
+
+func (s *Server) HandleTeamSettingsGet(c *gin.Context) {
+	teamName := c.Query("team_name")
+	if teamName == "" {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	settings, err := s.Usecase.GetTeamSettings(c.Request.Context(), teamName)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+func (s *Server) HandleTeamSettingsUpdate(c *gin.Context) {
+	var req entities.UpdateTeamSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	settings, err := s.Usecase.UpdateTeamSettings(c.Request.Context(), req)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"settings": settings,
+	})
+}
+
+func (s *Server) HandleTeamCodeownersGet(c *gin.Context) {
+	teamName := c.Query("team_name")
+	if teamName == "" {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	co, err := s.Usecase.GetCodeowners(c.Request.Context(), teamName)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"codeowners": co,
+	})
+}
+
+func (s *Server) HandleTeamCodeownersUpload(c *gin.Context) {
+	var req entities.UploadCodeownersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	co, err := s.Usecase.UploadCodeowners(c.Request.Context(), req)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"codeowners": co,
+	})
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"net/http"
+	"pr-service/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) HandleTenantCreate(c *gin.Context) {
+	var req entities.CreateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	tenant, err := s.Usecase.CreateTenant(c.Request.Context(), req)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"tenant": tenant,
+	})
+}
+
+func (s *Server) HandleTenantList(c *gin.Context) {
+	tenants, err := s.Usecase.ListTenants(c.Request.Context())
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tenants": tenants,
+	})
+}
+
+func (s *Server) HandleWebhookSecretRotate(c *gin.Context) {
+	var req entities.RotateWebhookSecretRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	secret, err := s.Usecase.RotateWebhookSecret(c.Request.Context(), req)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"webhook_secret": secret,
+	})
+}
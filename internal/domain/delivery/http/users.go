@@ -3,6 +3,7 @@ package http
 import (
 	"net/http"
 	"pr-service/internal/domain/entities"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -32,7 +33,17 @@ func (s *Server) HandleGetUserReview(c *gin.Context) {
 		return
 	}
 
-	resp, err := s.Usecase.GetUserReviews(c.Request.Context(), userID)
+	onlyPending := false
+	if raw := c.Query("pending"); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		onlyPending = v
+	}
+
+	resp, err := s.Usecase.GetUserReviews(c.Request.Context(), userID, onlyPending)
 	if err != nil {
 		s.handleError(c, err)
 		return
@@ -40,3 +51,57 @@ func (s *Server) HandleGetUserReview(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+func (s *Server) HandleLinkIdentity(c *gin.Context) {
+	var req entities.LinkIdentityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	identity, err := s.Usecase.LinkIdentity(c.Request.Context(), req)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"identity": identity,
+	})
+}
+
+func (s *Server) HandleSetCapacity(c *gin.Context) {
+	var req entities.SetCapacityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	capacity, err := s.Usecase.SetUserCapacity(c.Request.Context(), req)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"capacity": capacity,
+	})
+}
+
+func (s *Server) HandleSetRole(c *gin.Context) {
+	var req entities.SetRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	role, err := s.Usecase.SetUserRole(c.Request.Context(), req)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": role,
+	})
+}
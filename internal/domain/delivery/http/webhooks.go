@@ -0,0 +1,54 @@
+package http
+
+import (
+	"net/http"
+	"pr-service/internal/domain/entities"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) HandleWebhookCreate(c *gin.Context) {
+	var req entities.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	wh, err := s.Usecase.CreateWebhook(c.Request.Context(), req)
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"webhook": wh,
+	})
+}
+
+func (s *Server) HandleWebhookList(c *gin.Context) {
+	webhooks, err := s.Usecase.ListWebhooks(c.Request.Context())
+	if err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"webhooks": webhooks,
+	})
+}
+
+func (s *Server) HandleWebhookDelete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	if err := s.Usecase.DeleteWebhook(c.Request.Context(), id); err != nil {
+		s.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,73 @@
+package entities
+
+import "context"
+
+const SystemActor = "system"
+
+type actorKey struct{}
+
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return SystemActor
+}
+
+const (
+	AuthMethodAPIKey     = "api_key"
+	AuthMethodJWT        = "jwt"
+	AuthMethodHeader     = "header"
+	AuthMethodAdminToken = "admin_token"
+	AuthMethodSystem     = "system"
+)
+
+// Principal is the caller of a request. UserID and TeamName are empty for
+// callers not mapped to a user.
+type Principal struct {
+	Subject  string
+	Method   string
+	APIKeyID int64
+	TenantID string
+	UserID   string
+	TeamName string
+	Role     string
+}
+
+// SystemPrincipal is used by background jobs and verified integration
+// webhooks.
+var SystemPrincipal = Principal{
+	Subject: SystemActor,
+	Method:  AuthMethodSystem,
+	Role:    RoleAdmin,
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// DefaultTenant holds the data of deployments that do not use tenants.
+const DefaultTenant = "default"
+
+type tenantKey struct{}
+
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+func TenantFromContext(ctx context.Context) string {
+	if tenantID, ok := ctx.Value(tenantKey{}).(string); ok && tenantID != "" {
+		return tenantID
+	}
+	return DefaultTenant
+}
@@ -1,18 +1,53 @@
 package entities
 
+import "time"
+
 type SetIsActiveRequest struct {
 	UserID   string `json:"user_id" binding:"required"`
 	IsActive *bool  `json:"is_active" binding:"required"`
 }
 
+type SetCapacityRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	// MaxOpenReviews overrides the team default; null resets it, 0 means unlimited.
+	MaxOpenReviews *int `json:"max_open_reviews" binding:"omitempty,min=0"`
+}
+
+type CreateUnavailabilityRequest struct {
+	UserID   string    `json:"user_id" binding:"required"`
+	StartsAt time.Time `json:"startsAt" binding:"required"`
+	EndsAt   time.Time `json:"endsAt" binding:"required,gtfield=StartsAt"`
+	Reason   string    `json:"reason" binding:"max=200"`
+}
+
+type UpdateUnavailabilityRequest struct {
+	ID       int64     `json:"id" binding:"required"`
+	StartsAt time.Time `json:"startsAt" binding:"required"`
+	EndsAt   time.Time `json:"endsAt" binding:"required,gtfield=StartsAt"`
+	Reason   string    `json:"reason" binding:"max=200"`
+}
+
+type LinkIdentityRequest struct {
+	UserID   string `json:"user_id" binding:"required"`
+	Provider string `json:"provider" binding:"required,oneof=github gitlab"`
+	Login    string `json:"login" binding:"required"`
+}
+
 type CreatePullRequestRequest struct {
-	PullRequestID   string `json:"pull_request_id" binding:"required"`
-	PullRequestName string `json:"pull_request_name" binding:"required"`
-	AuthorID        string `json:"author_id" binding:"required"`
+	PullRequestID   string   `json:"pull_request_id" binding:"required"`
+	PullRequestName string   `json:"pull_request_name" binding:"required"`
+	AuthorID        string   `json:"author_id" binding:"required"`
+	Draft           bool     `json:"draft"`
+	ChangedFiles    []string `json:"changed_files" binding:"omitempty,dive,required"`
 }
 
 type MergePullRequestRequest struct {
 	PullRequestID string `json:"pull_request_id" binding:"required"`
+	Force         bool   `json:"force"`
+}
+
+type PullRequestStatusRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
 }
 
 type ReassignReviewerRequest struct {
@@ -20,7 +55,73 @@ type ReassignReviewerRequest struct {
 	OldReviewerID string `json:"old_reviewer_id" binding:"required"`
 }
 
+type SubmitReviewRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
+	ReviewerID    string `json:"reviewer_id" binding:"required"`
+	State         string `json:"state" binding:"required,oneof=APPROVED CHANGES_REQUESTED COMMENTED"`
+}
+
+type ApprovePullRequestRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
+	ReviewerID    string `json:"reviewer_id" binding:"required"`
+}
+
 type BulkDeactivateRequest struct {
 	TeamName string   `json:"team_name" binding:"required"`
 	UserIDs  []string `json:"user_ids" binding:"required"`
 }
+
+type UpdateTeamSettingsRequest struct {
+	TeamName             string    `json:"team_name" binding:"required"`
+	AssignmentStrategy   *string   `json:"assignment_strategy" binding:"omitempty,oneof=random round_robin least_loaded weighted"`
+	ReviewersCount       *int      `json:"reviewers_count" binding:"omitempty,min=1,max=10"`
+	MinApprovals         *int      `json:"min_approvals" binding:"omitempty,min=0,max=10"`
+	RequireAllApproved   *bool     `json:"require_all_approved"`
+	FallbackTeams        *[]string `json:"fallback_teams" binding:"omitempty,max=5,dive,required"`
+	MaxOpenReviews       *int      `json:"max_open_reviews" binding:"omitempty,min=0"`
+	PreferWorkingHours   *bool     `json:"prefer_working_hours"`
+	ReminderAfterMinutes *int      `json:"reminder_after_minutes" binding:"omitempty,min=0"`
+	EscalateAfterMinutes *int      `json:"escalate_after_minutes" binding:"omitempty,min=0"`
+}
+
+type UploadCodeownersRequest struct {
+	TeamName string `json:"team_name" binding:"required"`
+	Content  string `json:"content" binding:"required"`
+}
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required,url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types" binding:"dive,oneof=reviewer.assigned reviewer.reassigned reviewer.unassigned reviewer.deactivated pull_request.merged pull_request.closed pull_request.reopened"`
+}
+
+// AssignmentsStatsFilter narrows /stats/assignments; zero values mean no
+// restriction. From/To bound the time the reviewer was assigned.
+type AssignmentsStatsFilter struct {
+	TeamName string
+	Status   string
+	From     time.Time
+	To       time.Time
+}
+
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	// UserID binds the key to a user whose role it acts with; unbound keys
+	// are read-only.
+	UserID string `json:"user_id"`
+}
+
+type CreateTenantRequest struct {
+	TenantID string `json:"tenant_id" binding:"required,max=64"`
+	Name     string `json:"name" binding:"required,max=200"`
+}
+
+type RotateWebhookSecretRequest struct {
+	TenantID string `json:"tenant_id" binding:"required"`
+	Provider string `json:"provider" binding:"required,oneof=github gitlab"`
+}
+
+type SetRoleRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	Role   string `json:"role" binding:"required,oneof=ADMIN TEAM_LEAD DEVELOPER READ_ONLY"`
+}
@@ -3,9 +3,12 @@ package entities
 import "time"
 
 type TeamMember struct {
-	UserID   string `json:"user_id" db:"user_id"`
-	Username string `json:"username" db:"username"`
-	IsActive bool   `json:"is_active" db:"is_active"`
+	UserID    string `json:"user_id" db:"user_id"`
+	Username  string `json:"username" db:"username"`
+	IsActive  bool   `json:"is_active" db:"is_active"`
+	Timezone  string `json:"timezone" db:"timezone"`
+	WorkStart string `json:"work_start" db:"work_start"`
+	WorkEnd   string `json:"work_end" db:"work_end"`
 }
 
 type Team struct {
@@ -13,39 +16,230 @@ type Team struct {
 	Members  []TeamMember `json:"members"`
 }
 
+type TeamSettings struct {
+	TeamName           string   `json:"team_name" db:"team_name"`
+	AssignmentStrategy string   `json:"assignment_strategy" db:"assignment_strategy"`
+	ReviewersCount     int      `json:"reviewers_count" db:"reviewers_count"`
+	MinApprovals       int      `json:"min_approvals" db:"min_approvals"`
+	RequireAllApproved bool     `json:"require_all_approved" db:"require_all_approved"`
+	FallbackTeams      []string `json:"fallback_teams" db:"fallback_teams"`
+	// MaxOpenReviews is the default per-member cap on OPEN reviews, 0 means unlimited.
+	MaxOpenReviews int `json:"max_open_reviews" db:"max_open_reviews"`
+	// PreferWorkingHours picks members inside their working hours first.
+	PreferWorkingHours bool `json:"prefer_working_hours" db:"prefer_working_hours"`
+	// ReminderAfterMinutes and EscalateAfterMinutes are the review SLA for
+	// PENDING reviewers, 0 disables the step.
+	ReminderAfterMinutes int `json:"reminder_after_minutes" db:"reminder_after_minutes"`
+	EscalateAfterMinutes int `json:"escalate_after_minutes" db:"escalate_after_minutes"`
+}
+
+type TeamCodeowners struct {
+	TeamName  string    `json:"team_name" db:"team_name"`
+	Content   string    `json:"content" db:"content"`
+	Rules     int       `json:"rules"`
+	UpdatedAt time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+const (
+	AssignmentStrategyRandom      = "random"
+	AssignmentStrategyRoundRobin  = "round_robin"
+	AssignmentStrategyLeastLoaded = "least_loaded"
+	AssignmentStrategyWeighted    = "weighted"
+)
+
+// Rotation repeats the round_robin picks of an assignment from the cursors
+// locked in the transaction that stores it, so concurrent assignments of a
+// team take turns instead of starting from the same cursor. Teams are sorted
+// so that cursors are always locked in the same order; Pick returns all
+// reviewers of the assignment and the new cursor of each team.
+type Rotation struct {
+	Teams []string
+	Pick  func(cursors map[string]string) (reviewers []string, next map[string]string)
+}
+
+const (
+	DefaultAssignmentStrategy = AssignmentStrategyLeastLoaded
+	DefaultReviewersCount     = 2
+)
+
 type User struct {
-	UserID   string `json:"user_id" db:"user_id"`
-	Username string `json:"username" db:"username"`
-	TeamName string `json:"team_name" db:"team_name"`
-	IsActive bool   `json:"is_active" db:"is_active"`
+	UserID    string `json:"user_id" db:"user_id"`
+	Username  string `json:"username" db:"username"`
+	TeamName  string `json:"team_name" db:"team_name"`
+	IsActive  bool   `json:"is_active" db:"is_active"`
+	Timezone  string `json:"timezone" db:"timezone"`
+	WorkStart string `json:"work_start" db:"work_start"`
+	WorkEnd   string `json:"work_end" db:"work_end"`
 }
 
+const (
+	DefaultTimezone  = "UTC"
+	DefaultWorkStart = "09:00"
+	DefaultWorkEnd   = "18:00"
+)
+
 type PullRequest struct {
-	PullRequestID     string     `json:"pull_request_id" db:"pull_request_id"`
-	PullRequestName   string     `json:"pull_request_name" db:"pull_request_name"`
-	AuthorID          string     `json:"author_id" db:"author_id"`
-	Status            string     `json:"status" db:"status"`
-	AssignedReviewers []string   `json:"assigned_reviewers"`
-	CreatedAt         time.Time  `json:"createdAt" db:"created_at"`
-	MergedAt          *time.Time `json:"mergedAt,omitempty" db:"merged_at"`
+	PullRequestID     string          `json:"pull_request_id" db:"pull_request_id"`
+	PullRequestName   string          `json:"pull_request_name" db:"pull_request_name"`
+	AuthorID          string          `json:"author_id" db:"author_id"`
+	Status            string          `json:"status" db:"status"`
+	AssignedReviewers []string        `json:"assigned_reviewers"`
+	Reviews           []ReviewerState `json:"reviews"`
+	CreatedAt         time.Time       `json:"createdAt" db:"created_at"`
+	MergedAt          *time.Time      `json:"mergedAt,omitempty" db:"merged_at"`
+	ClosedAt          *time.Time      `json:"closedAt,omitempty" db:"closed_at"`
+	ChangedFiles      []string        `json:"changed_files,omitempty" db:"changed_files"`
+	FallbackReviewers []string        `json:"fallback_reviewers,omitempty"`
+	UnderStaffed      bool            `json:"under_staffed,omitempty"`
 }
 
+const (
+	PullRequestStatusDraft  = "DRAFT"
+	PullRequestStatusOpen   = "OPEN"
+	PullRequestStatusMerged = "MERGED"
+	PullRequestStatusClosed = "CLOSED"
+)
+
 type PullRequestShort struct {
 	PullRequestID   string `json:"pull_request_id" db:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name" db:"pull_request_name"`
 	AuthorID        string `json:"author_id" db:"author_id"`
 	Status          string `json:"status" db:"status"`
+	ReviewState     string `json:"review_state" db:"state"`
+}
+
+type ReviewerState struct {
+	ReviewerID string     `json:"reviewer_id" db:"reviewer_id"`
+	State      string     `json:"state" db:"state"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty" db:"state_updated_at"`
+	// FallbackTeam is set when the reviewer is not from the author's team.
+	FallbackTeam string `json:"fallback_team,omitempty"`
+}
+
+const (
+	ReviewStatePending          = "PENDING"
+	ReviewStateApproved         = "APPROVED"
+	ReviewStateChangesRequested = "CHANGES_REQUESTED"
+	ReviewStateCommented        = "COMMENTED"
+)
+
+type AssignmentEvent struct {
+	ID            int64     `json:"id" db:"id"`
+	PullRequestID string    `json:"pull_request_id" db:"pull_request_id"`
+	EventType     string    `json:"event_type" db:"event_type"`
+	OldReviewerID string    `json:"old_reviewer_id,omitempty" db:"old_reviewer_id"`
+	NewReviewerID string    `json:"new_reviewer_id,omitempty" db:"new_reviewer_id"`
+	Reason        string    `json:"reason,omitempty" db:"reason"`
+	Actor         string    `json:"actor" db:"actor"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+const (
+	AssignmentEventAssign     = "ASSIGN"
+	AssignmentEventReassign   = "REASSIGN"
+	AssignmentEventUnassign   = "UNASSIGN"
+	AssignmentEventMerge      = "MERGE"
+	AssignmentEventDeactivate = "DEACTIVATE"
+	AssignmentEventClose      = "CLOSE"
+	AssignmentEventReopen     = "REOPEN"
+)
+
+type PullRequestHistoryResponse struct {
+	PullRequestID string            `json:"pull_request_id"`
+	Events        []AssignmentEvent `json:"events"`
+}
+
+type Webhook struct {
+	ID         int64     `json:"id" db:"id"`
+	URL        string    `json:"url" db:"url"`
+	Secret     string    `json:"secret,omitempty" db:"secret"`
+	EventTypes []string  `json:"event_types" db:"event_types"`
+	IsActive   bool      `json:"is_active" db:"is_active"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+const (
+	WebhookEventReviewerAssigned    = "reviewer.assigned"
+	WebhookEventReviewerReassigned  = "reviewer.reassigned"
+	WebhookEventReviewerUnassigned  = "reviewer.unassigned"
+	WebhookEventReviewerDeactivated = "reviewer.deactivated"
+	WebhookEventPullRequestMerged   = "pull_request.merged"
+	WebhookEventPullRequestClosed   = "pull_request.closed"
+	WebhookEventPullRequestReopened = "pull_request.reopened"
+)
+
+type WebhookPayload struct {
+	ID         int64           `json:"id"`
+	TenantID   string          `json:"tenant_id"`
+	Event      string          `json:"event"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       AssignmentEvent `json:"data"`
+}
+
+type OutboxMessage struct {
+	ID        int64  `db:"id"`
+	WebhookID int64  `db:"webhook_id"`
+	URL       string `db:"url"`
+	Secret    string `db:"secret"`
+	EventType string `db:"event_type"`
+	Payload   []byte `db:"payload"`
+	Attempts  int    `db:"attempts"`
+}
+
+type UserIdentity struct {
+	Provider string `json:"provider" db:"provider"`
+	Login    string `json:"login" db:"external_login"`
+	UserID   string `json:"user_id" db:"user_id"`
+}
+
+const (
+	IdentityProviderGitHub = "github"
+	IdentityProviderGitLab = "gitlab"
+)
+
+type ExternalPullRequestEvent struct {
+	Provider      string
+	Action        string
+	PullRequestID string
+	Title         string
+	AuthorLogin   string
+	SenderLogin   string
+	Draft         bool
+}
+
+const (
+	ExternalActionOpened   = "opened"
+	ExternalActionReady    = "ready"
+	ExternalActionClosed   = "closed"
+	ExternalActionMerged   = "merged"
+	ExternalActionReopened = "reopened"
+)
+
+type ExternalEventResult struct {
+	Action      string       `json:"action"`
+	Ignored     bool         `json:"ignored,omitempty"`
+	PullRequest *PullRequest `json:"pr,omitempty"`
 }
 
 type ErrorCode string
 
 const (
-	ErrorCodeTeamExists  ErrorCode = "TEAM_EXISTS"
-	ErrorCodePRExists    ErrorCode = "PR_EXISTS"
-	ErrorCodePRMerged    ErrorCode = "PR_MERGED"
-	ErrorCodeNotAssigned ErrorCode = "NOT_ASSIGNED"
-	ErrorCodeNoCandidate ErrorCode = "NO_CANDIDATE"
-	ErrorCodeNotFound    ErrorCode = "NOT_FOUND"
+	ErrorCodeTeamExists      ErrorCode = "TEAM_EXISTS"
+	ErrorCodePRExists        ErrorCode = "PR_EXISTS"
+	ErrorCodePRMerged        ErrorCode = "PR_MERGED"
+	ErrorCodeNotAssigned     ErrorCode = "NOT_ASSIGNED"
+	ErrorCodeNoCandidate     ErrorCode = "NO_CANDIDATE"
+	ErrorCodeNotFound        ErrorCode = "NOT_FOUND"
+	ErrorCodeNotApproved     ErrorCode = "NOT_APPROVED"
+	ErrorCodeInvalidStatus   ErrorCode = "INVALID_STATUS_TRANSITION"
+	ErrorCodeUnknownIdentity ErrorCode = "UNKNOWN_IDENTITY"
+	ErrorCodeInvalidOwners   ErrorCode = "INVALID_CODEOWNERS"
+	ErrorCodeInvalidSettings ErrorCode = "INVALID_SETTINGS"
+	ErrorCodeInvalidWindow   ErrorCode = "INVALID_WINDOW"
+	ErrorCodeUnauthorized    ErrorCode = "UNAUTHORIZED"
+	ErrorCodeForbidden       ErrorCode = "FORBIDDEN"
+	ErrorCodeTenantExists    ErrorCode = "TENANT_EXISTS"
+	ErrorCodeIdentityLinked  ErrorCode = "IDENTITY_LINKED"
 )
 
 type ErrorBody struct {
@@ -67,21 +261,205 @@ func (e *DomainError) Error() string {
 }
 
 type GetUserReviewsResponse struct {
-	UserID       string             `json:"user_id"`
-	PullRequests []PullRequestShort `json:"pull_requests"`
+	UserID         string             `json:"user_id"`
+	PullRequests   []PullRequestShort `json:"pull_requests"`
+	OpenReviews    int                `json:"open_reviews"`
+	MaxOpenReviews int                `json:"max_open_reviews"`
+	AtCapacity     bool               `json:"at_capacity"`
+}
+
+type Unavailability struct {
+	TenantID     string     `json:"-" db:"tenant_id"`
+	ID           int64      `json:"id" db:"id"`
+	UserID       string     `json:"user_id" db:"user_id"`
+	StartsAt     time.Time  `json:"startsAt" db:"starts_at"`
+	EndsAt       time.Time  `json:"endsAt" db:"ends_at"`
+	Reason       string     `json:"reason,omitempty" db:"reason"`
+	ReassignedAt *time.Time `json:"reassignedAt,omitempty" db:"reassigned_at"`
+	CreatedAt    time.Time  `json:"createdAt" db:"created_at"`
+}
+
+type StaleReview struct {
+	TenantID        string     `json:"tenant_id"`
+	PullRequestID   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	AuthorID        string     `json:"author_id"`
+	ReviewerID      string     `json:"reviewer_id"`
+	TeamName        string     `json:"team_name"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	AssignedAt      time.Time  `json:"assignedAt"`
+	RemindedAt      *time.Time `json:"remindedAt,omitempty"`
+	Escalate        bool       `json:"-"`
+	EscalateAfter   int        `json:"-"`
+}
+
+type ReviewReminder struct {
+	Event          string      `json:"event"`
+	OccurredAt     time.Time   `json:"occurred_at"`
+	WaitingMinutes int         `json:"waiting_minutes"`
+	Review         StaleReview `json:"review"`
+}
+
+const EventReviewReminder = "review.reminder"
+
+type ReviewCapacity struct {
+	UserID string `json:"user_id"`
+	// Override is the user's own cap; nil means the team default applies.
+	Override       *int `json:"max_open_reviews_override"`
+	MaxOpenReviews int  `json:"max_open_reviews"`
+	OpenReviews    int  `json:"open_reviews"`
+	AtCapacity     bool `json:"at_capacity"`
 }
 
 type ReviewerAssignmentsStat struct {
 	UserID      string `json:"user_id" db:"user_id"`
+	TeamName    string `json:"team_name" db:"team_name"`
+	IsActive    bool   `json:"is_active" db:"is_active"`
 	Assignments int    `json:"assignments" db:"assignments"`
 }
 
+// TeamAssignmentsStat groups reviewers by their own team. Fairness figures
+// are computed over active members only.
+type TeamAssignmentsStat struct {
+	TeamName       string `json:"team_name"`
+	Assignments    int    `json:"assignments"`
+	ActiveMembers  int    `json:"active_members"`
+	MinAssignments int    `json:"min_assignments"`
+	MaxAssignments int    `json:"max_assignments"`
+	// MaxMinRatio is null when some active member has no assignments.
+	MaxMinRatio *float64                  `json:"max_min_ratio"`
+	Gini        float64                   `json:"gini"`
+	Reviewers   []ReviewerAssignmentsStat `json:"reviewers"`
+}
+
 type AssignmentsStatsResponse struct {
 	Reviewers []ReviewerAssignmentsStat `json:"reviewers"`
+	Teams     []TeamAssignmentsStat     `json:"teams"`
+}
+
+// ReviewTiming is one reviewer assignment on a PR created inside the stats
+// window; ReviewerID is empty for PRs that never had reviewers.
+type ReviewTiming struct {
+	PullRequestID   string
+	TeamName        string
+	ReviewerID      string
+	CreatedAt       time.Time
+	MergedAt        *time.Time
+	AssignedAt      *time.Time
+	FirstResponseAt *time.Time
+	ApprovedAt      *time.Time
+}
+
+type DurationPercentiles struct {
+	Count      int   `json:"count"`
+	P50Seconds int64 `json:"p50_seconds"`
+	P90Seconds int64 `json:"p90_seconds"`
+	P99Seconds int64 `json:"p99_seconds"`
+}
+
+type SLAGroupStats struct {
+	TeamName          string              `json:"team_name,omitempty"`
+	UserID            string              `json:"user_id,omitempty"`
+	TimeToFirstReview DurationPercentiles `json:"time_to_first_review"`
+	TimeToApproval    DurationPercentiles `json:"time_to_approval"`
+	TimeToMerge       DurationPercentiles `json:"time_to_merge"`
+}
+
+type SLABreach struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        string    `json:"author_id"`
+	TeamName        string    `json:"team_name"`
+	ReviewerID      string    `json:"reviewer_id"`
+	AssignedAt      time.Time `json:"assignedAt"`
+	WaitingMinutes  int       `json:"waiting_minutes"`
+	SLAMinutes      int       `json:"sla_minutes"`
+	// Escalated is set once the escalation threshold has passed as well.
+	Escalated bool `json:"escalated"`
+}
+
+type SLAStatsResponse struct {
+	From      time.Time       `json:"from"`
+	To        time.Time       `json:"to"`
+	Teams     []SLAGroupStats `json:"teams"`
+	Reviewers []SLAGroupStats `json:"reviewers"`
+	Breaching []SLABreach     `json:"breaching"`
+}
+
+type APIKey struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	TenantID   string     `json:"tenant_id"`
+	UserID     *string    `json:"user_id,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// CreatedAPIKey is returned once, at creation; Key cannot be recovered later.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+const (
+	RoleAdmin     = "ADMIN"
+	RoleTeamLead  = "TEAM_LEAD"
+	RoleDeveloper = "DEVELOPER"
+	RoleReadOnly  = "READ_ONLY"
+)
+
+type UserRole struct {
+	UserID   string `json:"user_id"`
+	TeamName string `json:"team_name"`
+	Role     string `json:"role"`
+}
+
+// TeamKey names a team across tenants.
+type TeamKey struct {
+	TenantID string
+	TeamName string
+}
+
+type LoadSnapshot struct {
+	OpenPullRequests int
+	TeamOpenReviews  map[TeamKey]int
+}
+
+type Tenant struct {
+	TenantID  string    `json:"tenant_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TenantWebhookSecret signs inbound webhooks of one provider for a tenant;
+// the tenant of a webhook is the one whose secret verifies it.
+type TenantWebhookSecret struct {
+	TenantID string `json:"tenant_id"`
+	Provider string `json:"provider"`
+	Secret   string `json:"secret"`
 }
 
 type BulkDeactivateResult struct {
 	TeamName        string `json:"team_name"`
 	Deactivated     int    `json:"deactivated"`
 	ReassignedCount int    `json:"reassigned"`
+	FallbackCount   int    `json:"reassigned_to_fallback,omitempty"`
+}
+
+const (
+	CheckStatusOK   = "ok"
+	CheckStatusFail = "fail"
+)
+
+type ReadinessCheck struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type ReadinessReport struct {
+	Status string                    `json:"status"`
+	Checks map[string]ReadinessCheck `json:"checks"`
 }
@@ -0,0 +1,107 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pr-service/internal/domain/entities"
+	"sync/atomic"
+	"time"
+)
+
+const readyTimeout = 2 * time.Second
+
+var errShuttingDown = errors.New("server is shutting down")
+
+// Store is the part of the repository readiness depends on.
+type Store interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (version int64, dirty bool, err error)
+}
+
+type Worker interface {
+	Running() bool
+}
+
+// Checker answers readiness probes. It reports not ready once Drain is
+// called, so the instance is taken out of rotation before it stops serving.
+type Checker struct {
+	store         Store
+	schemaVersion int64
+	workers       map[string]Worker
+	draining      atomic.Bool
+}
+
+func NewChecker(store Store, schemaVersion int64, workers map[string]Worker) *Checker {
+	return &Checker{
+		store:         store,
+		schemaVersion: schemaVersion,
+		workers:       workers,
+	}
+}
+
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+func (c *Checker) Ready(ctx context.Context) entities.ReadinessReport {
+	ctx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+
+	report := entities.ReadinessReport{
+		Status: entities.CheckStatusOK,
+		Checks: make(map[string]entities.ReadinessCheck, len(c.workers)+3),
+	}
+	run := func(name string, fn func() error) {
+		start := time.Now()
+		err := fn()
+		check := entities.ReadinessCheck{
+			Status:    entities.CheckStatusOK,
+			LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+		}
+		if err != nil {
+			check.Status = entities.CheckStatusFail
+			check.Error = err.Error()
+			report.Status = entities.CheckStatusFail
+		}
+		report.Checks[name] = check
+	}
+
+	run("shutdown", func() error {
+		if c.draining.Load() {
+			return errShuttingDown
+		}
+		return nil
+	})
+	run("postgres", func() error {
+		return c.store.Ping(ctx)
+	})
+	run("migrations", func() error {
+		return c.checkMigrations(ctx)
+	})
+	for name, w := range c.workers {
+		run("worker:"+name, func() error {
+			if !w.Running() {
+				return errors.New("not running")
+			}
+			return nil
+		})
+	}
+	return report
+}
+
+// checkMigrations accepts a newer schema too: migrations are applied before
+// the new version rolls out, and old instances must stay in service.
+func (c *Checker) checkMigrations(ctx context.Context) error {
+	version, dirty, err := c.store.MigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("migration %d is dirty", version)
+	}
+	if version < c.schemaVersion {
+		return fmt.Errorf("schema version %d, expected %d", version, c.schemaVersion)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pr-service/internal/domain/entities"
+)
+
+type fakeStore struct {
+	pingErr error
+	version int64
+	dirty   bool
+}
+
+func (f *fakeStore) Ping(context.Context) error { return f.pingErr }
+
+func (f *fakeStore) MigrationVersion(context.Context) (int64, bool, error) {
+	return f.version, f.dirty, nil
+}
+
+type fakeWorker bool
+
+func (w fakeWorker) Running() bool { return bool(w) }
+
+func TestReady(t *testing.T) {
+	cases := []struct {
+		name    string
+		store   fakeStore
+		worker  fakeWorker
+		failing string
+	}{
+		{"healthy", fakeStore{version: 20}, true, ""},
+		{"newer schema", fakeStore{version: 21}, true, ""},
+		{"db down", fakeStore{pingErr: errors.New("refused"), version: 20}, true, "postgres"},
+		{"old schema", fakeStore{version: 19}, true, "migrations"},
+		{"dirty schema", fakeStore{version: 20, dirty: true}, true, "migrations"},
+		{"worker stopped", fakeStore{version: 20}, false, "worker:sla"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewChecker(&tc.store, 20, map[string]Worker{"sla": tc.worker})
+			report := c.Ready(context.Background())
+
+			wantStatus := entities.CheckStatusOK
+			if tc.failing != "" {
+				wantStatus = entities.CheckStatusFail
+			}
+			if report.Status != wantStatus {
+				t.Fatalf("status = %q, want %q: %+v", report.Status, wantStatus, report.Checks)
+			}
+			for name, check := range report.Checks {
+				if failed := check.Status == entities.CheckStatusFail; failed != (name == tc.failing) {
+					t.Errorf("check %s = %+v", name, check)
+				}
+			}
+		})
+	}
+}
+
+func TestReadyFailsWhileDraining(t *testing.T) {
+	c := NewChecker(&fakeStore{version: 20}, 20, nil)
+	if report := c.Ready(context.Background()); report.Status != entities.CheckStatusOK {
+		t.Fatalf("expected ready before drain: %+v", report)
+	}
+
+	c.Drain()
+	report := c.Ready(context.Background())
+	if report.Status != entities.CheckStatusFail || report.Checks["shutdown"].Status != entities.CheckStatusFail {
+		t.Fatalf("expected shutdown check to fail: %+v", report)
+	}
+}
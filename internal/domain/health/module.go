@@ -0,0 +1,27 @@
+package health
+
+import (
+	"pr-service/internal/domain/repository/postgres"
+	"pr-service/internal/domain/worker"
+
+	"go.uber.org/fx"
+)
+
+func New() fx.Option {
+	return fx.Module("health",
+		fx.Provide(
+			func(
+				repo *postgres.Repository,
+				d *worker.Dispatcher,
+				a *worker.AvailabilityJob,
+				s *worker.SLAScheduler,
+			) *Checker {
+				return NewChecker(repo, postgres.SchemaVersion, map[string]Worker{
+					"webhooks":     d,
+					"availability": a,
+					"sla":          s,
+				})
+			},
+		),
+	)
+}
@@ -0,0 +1,103 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"pr-service/internal/domain/entities"
+	"strings"
+)
+
+const (
+	SignatureHeader = "X-Hub-Signature-256"
+	EventHeader     = "X-GitHub-Event"
+
+	EventPing        = "ping"
+	EventPullRequest = "pull_request"
+)
+
+type User struct {
+	Login string `json:"login"`
+}
+
+type Repository struct {
+	FullName string `json:"full_name"`
+}
+
+type PullRequest struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Draft  bool   `json:"draft"`
+	Merged bool   `json:"merged"`
+	User   User   `json:"user"`
+}
+
+type PullRequestEvent struct {
+	Action      string      `json:"action"`
+	Number      int         `json:"number"`
+	PullRequest PullRequest `json:"pull_request"`
+	Repository  Repository  `json:"repository"`
+	Sender      User        `json:"sender"`
+}
+
+func VerifySignature(secret, body []byte, header string) bool {
+	sig, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+func ParsePullRequestEvent(body []byte) (PullRequestEvent, error) {
+	var ev PullRequestEvent
+	if err := json.Unmarshal(body, &ev); err != nil {
+		return PullRequestEvent{}, err
+	}
+	if ev.Repository.FullName == "" || ev.PullRequest.Number == 0 {
+		return PullRequestEvent{}, fmt.Errorf("pull_request event without repository or number")
+	}
+	return ev, nil
+}
+
+func PullRequestID(repoFullName string, number int) string {
+	return fmt.Sprintf("%s#%d", repoFullName, number)
+}
+
+// ToExternal maps the event onto the provider-neutral form; ok is false for
+// actions the service does not react to (labeled, synchronize, ...).
+func (e PullRequestEvent) ToExternal() (ev entities.ExternalPullRequestEvent, ok bool) {
+	ev = entities.ExternalPullRequestEvent{
+		Provider:      entities.IdentityProviderGitHub,
+		PullRequestID: PullRequestID(e.Repository.FullName, e.PullRequest.Number),
+		Title:         e.PullRequest.Title,
+		AuthorLogin:   e.PullRequest.User.Login,
+		SenderLogin:   e.Sender.Login,
+		Draft:         e.PullRequest.Draft,
+	}
+
+	switch e.Action {
+	case "opened":
+		ev.Action = entities.ExternalActionOpened
+	case "ready_for_review":
+		ev.Action = entities.ExternalActionReady
+	case "reopened":
+		ev.Action = entities.ExternalActionReopened
+	case "closed":
+		ev.Action = entities.ExternalActionClosed
+		if e.PullRequest.Merged {
+			ev.Action = entities.ExternalActionMerged
+		}
+	default:
+		return ev, false
+	}
+	return ev, true
+}
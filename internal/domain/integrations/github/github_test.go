@@ -0,0 +1,100 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"pr-service/internal/domain/entities"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture %s: %v", name, err)
+	}
+	return body
+}
+
+func sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySignature(t *testing.T) {
+	secret := []byte("It's a Secret to Everybody")
+	body := readFixture(t, "pull_request_opened.json")
+
+	if !VerifySignature(secret, body, sign(secret, body)) {
+		t.Fatalf("expected valid signature to verify")
+	}
+	if VerifySignature([]byte("other"), body, sign(secret, body)) {
+		t.Fatalf("expected signature with wrong secret to fail")
+	}
+	if VerifySignature(secret, append(body, ' '), sign(secret, body)) {
+		t.Fatalf("expected signature over modified body to fail")
+	}
+	if VerifySignature(secret, body, "sha1=deadbeef") || VerifySignature(secret, body, "") {
+		t.Fatalf("expected malformed headers to fail")
+	}
+}
+
+func TestParsePullRequestEventFixtures(t *testing.T) {
+	cases := []struct {
+		fixture string
+		action  string
+		ok      bool
+		draft   bool
+	}{
+		{"pull_request_opened.json", entities.ExternalActionOpened, true, false},
+		{"pull_request_opened_draft.json", entities.ExternalActionOpened, true, true},
+		{"pull_request_ready_for_review.json", entities.ExternalActionReady, true, false},
+		{"pull_request_closed.json", entities.ExternalActionClosed, true, false},
+		{"pull_request_closed_merged.json", entities.ExternalActionMerged, true, false},
+		{"pull_request_labeled.json", "", false, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.fixture, func(t *testing.T) {
+			ev, err := ParsePullRequestEvent(readFixture(t, tc.fixture))
+			if err != nil {
+				t.Fatalf("ParsePullRequestEvent: %v", err)
+			}
+
+			ext, ok := ev.ToExternal()
+			if ok != tc.ok {
+				t.Fatalf("expected ok=%v, got %v", tc.ok, ok)
+			}
+			if !ok {
+				return
+			}
+			if ext.Action != tc.action {
+				t.Fatalf("expected action %q, got %q", tc.action, ext.Action)
+			}
+			if ext.PullRequestID != "acme/pr-service#42" {
+				t.Fatalf("unexpected pull request id %q", ext.PullRequestID)
+			}
+			if ext.AuthorLogin != "Octo-Dev" || ext.Provider != entities.IdentityProviderGitHub {
+				t.Fatalf("unexpected author/provider: %+v", ext)
+			}
+			if ext.Draft != tc.draft {
+				t.Fatalf("expected draft=%v, got %v", tc.draft, ext.Draft)
+			}
+		})
+	}
+}
+
+func TestParsePullRequestEventRejectsIncompletePayload(t *testing.T) {
+	if _, err := ParsePullRequestEvent([]byte(`{"action":"opened"}`)); err == nil {
+		t.Fatalf("expected error for payload without repository and number")
+	}
+	if _, err := ParsePullRequestEvent([]byte(`not json`)); err == nil {
+		t.Fatalf("expected error for invalid json")
+	}
+}
@@ -0,0 +1,108 @@
+package gitlab
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"pr-service/internal/domain/entities"
+)
+
+const (
+	TokenHeader = "X-Gitlab-Token"
+	EventHeader = "X-Gitlab-Event"
+
+	EventMergeRequest = "Merge Request Hook"
+)
+
+type User struct {
+	Username string `json:"username"`
+}
+
+type Project struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+}
+
+type MergeRequestAttributes struct {
+	IID    int    `json:"iid"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	Action string `json:"action"`
+	Draft  bool   `json:"draft"`
+}
+
+type BoolChange struct {
+	Previous bool `json:"previous"`
+	Current  bool `json:"current"`
+}
+
+type Changes struct {
+	Draft *BoolChange `json:"draft"`
+}
+
+type MergeRequestEvent struct {
+	ObjectKind       string                 `json:"object_kind"`
+	User             User                   `json:"user"`
+	Project          Project                `json:"project"`
+	ObjectAttributes MergeRequestAttributes `json:"object_attributes"`
+	Changes          Changes                `json:"changes"`
+}
+
+func VerifyToken(expected, got string) bool {
+	if expected == "" || got == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(got)) == 1
+}
+
+func ParseMergeRequestEvent(body []byte) (MergeRequestEvent, error) {
+	var ev MergeRequestEvent
+	if err := json.Unmarshal(body, &ev); err != nil {
+		return MergeRequestEvent{}, err
+	}
+	if ev.ObjectKind != "merge_request" {
+		return MergeRequestEvent{}, fmt.Errorf("unexpected object_kind %q", ev.ObjectKind)
+	}
+	if ev.Project.PathWithNamespace == "" || ev.ObjectAttributes.IID == 0 {
+		return MergeRequestEvent{}, fmt.Errorf("merge_request event without project or iid")
+	}
+	return ev, nil
+}
+
+func PullRequestID(projectPath string, iid int) string {
+	return fmt.Sprintf("%s!%d", projectPath, iid)
+}
+
+// ToExternal maps the event onto the provider-neutral form; ok is false for
+// actions the service does not react to. GitLab only reports the numeric
+// author id, so on "open" the triggering user is taken as the author.
+// An "update" is only meaningful when it takes the merge request out of draft.
+func (e MergeRequestEvent) ToExternal() (ev entities.ExternalPullRequestEvent, ok bool) {
+	attrs := e.ObjectAttributes
+	ev = entities.ExternalPullRequestEvent{
+		Provider:      entities.IdentityProviderGitLab,
+		PullRequestID: PullRequestID(e.Project.PathWithNamespace, attrs.IID),
+		Title:         attrs.Title,
+		AuthorLogin:   e.User.Username,
+		SenderLogin:   e.User.Username,
+		Draft:         attrs.Draft,
+	}
+
+	switch attrs.Action {
+	case "open":
+		ev.Action = entities.ExternalActionOpened
+	case "update":
+		if e.Changes.Draft == nil || !e.Changes.Draft.Previous || e.Changes.Draft.Current {
+			return ev, false
+		}
+		ev.Action = entities.ExternalActionReady
+	case "reopen":
+		ev.Action = entities.ExternalActionReopened
+	case "close":
+		ev.Action = entities.ExternalActionClosed
+	case "merge":
+		ev.Action = entities.ExternalActionMerged
+	default:
+		return ev, false
+	}
+	return ev, true
+}
@@ -0,0 +1,83 @@
+package gitlab
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"pr-service/internal/domain/entities"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture %s: %v", name, err)
+	}
+	return body
+}
+
+func TestVerifyToken(t *testing.T) {
+	if !VerifyToken("s3cret", "s3cret") {
+		t.Fatalf("expected matching token to verify")
+	}
+	if VerifyToken("s3cret", "other") || VerifyToken("s3cret", "") || VerifyToken("", "") {
+		t.Fatalf("expected mismatched or empty tokens to fail")
+	}
+}
+
+func TestParseMergeRequestEventFixtures(t *testing.T) {
+	cases := []struct {
+		fixture string
+		action  string
+		ok      bool
+		draft   bool
+	}{
+		{"merge_request_open.json", entities.ExternalActionOpened, true, false},
+		{"merge_request_open_draft.json", entities.ExternalActionOpened, true, true},
+		{"merge_request_update_ready.json", entities.ExternalActionReady, true, false},
+		{"merge_request_update_title.json", "", false, false},
+		{"merge_request_close.json", entities.ExternalActionClosed, true, false},
+		{"merge_request_reopen.json", entities.ExternalActionReopened, true, false},
+		{"merge_request_merge.json", entities.ExternalActionMerged, true, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.fixture, func(t *testing.T) {
+			ev, err := ParseMergeRequestEvent(readFixture(t, tc.fixture))
+			if err != nil {
+				t.Fatalf("ParseMergeRequestEvent: %v", err)
+			}
+
+			ext, ok := ev.ToExternal()
+			if ok != tc.ok {
+				t.Fatalf("expected ok=%v, got %v", tc.ok, ok)
+			}
+			if !ok {
+				return
+			}
+			if ext.Action != tc.action {
+				t.Fatalf("expected action %q, got %q", tc.action, ext.Action)
+			}
+			if ext.PullRequestID != "acme/pr-service!7" {
+				t.Fatalf("unexpected pull request id %q", ext.PullRequestID)
+			}
+			if ext.AuthorLogin != "Lab-Dev" || ext.Provider != entities.IdentityProviderGitLab {
+				t.Fatalf("unexpected author/provider: %+v", ext)
+			}
+			if ext.Draft != tc.draft {
+				t.Fatalf("expected draft=%v, got %v", tc.draft, ext.Draft)
+			}
+		})
+	}
+}
+
+func TestParseMergeRequestEventRejectsOtherKinds(t *testing.T) {
+	if _, err := ParseMergeRequestEvent([]byte(`{"object_kind":"push"}`)); err == nil {
+		t.Fatalf("expected error for non merge_request payload")
+	}
+	if _, err := ParseMergeRequestEvent([]byte(`{"object_kind":"merge_request"}`)); err == nil {
+		t.Fatalf("expected error for payload without project and iid")
+	}
+}
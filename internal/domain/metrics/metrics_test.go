@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"pr-service/internal/domain/entities"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.uber.org/zap"
+)
+
+type fakeStore struct {
+	snap entities.LoadSnapshot
+	err  error
+}
+
+func (f *fakeStore) PoolStat() *pgxpool.Stat { return nil }
+
+func (f *fakeStore) GetLoadSnapshot(context.Context) (entities.LoadSnapshot, error) {
+	return f.snap, f.err
+}
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	srv := httptest.NewServer(m.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("scrape: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return string(body)
+}
+
+func assertContains(t *testing.T, body string, lines ...string) {
+	t.Helper()
+	for _, l := range lines {
+		if !strings.Contains(body, l) {
+			t.Errorf("metrics output missing %q", l)
+		}
+	}
+}
+
+func TestMetricsExposition(t *testing.T) {
+	m := NewMetrics()
+	if err := m.Register(NewStoreCollector(zap.NewNop(), &fakeStore{snap: entities.LoadSnapshot{
+		OpenPullRequests: 3,
+		TeamOpenReviews: map[entities.TeamKey]int{
+			{TenantID: entities.DefaultTenant, TeamName: "backend"}:  5,
+			{TenantID: entities.DefaultTenant, TeamName: "frontend"}: 0,
+		},
+	}})); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	m.ObserveHTTPRequest(http.MethodPost, "/pullRequest/create", http.StatusCreated, 30*time.Millisecond)
+	m.ObserveHTTPRequest(http.MethodPost, "/pullRequest/create", http.StatusCreated, 10*time.Millisecond)
+	m.PullRequestCreated()
+	m.PullRequestMerged()
+	m.ReviewersReassigned(ReassignSourceDeactivate, 4)
+	m.ReviewersReassigned(ReassignSourceManual, 0)
+	m.NoCandidate("reassign")
+
+	body := scrape(t, m)
+	assertContains(t, body,
+		`pr_service_http_requests_total{method="POST",route="/pullRequest/create",status="201"} 2`,
+		`pr_service_http_request_duration_seconds_count{method="POST",route="/pullRequest/create"} 2`,
+		`pr_service_pull_requests_created_total 1`,
+		`pr_service_pull_requests_merged_total 1`,
+		`pr_service_reviewer_reassignments_total{source="deactivate"} 4`,
+		`pr_service_no_candidate_errors_total{operation="reassign"} 1`,
+		`pr_service_open_pull_requests 3`,
+		`pr_service_team_open_reviews{team="backend",tenant="default"} 5`,
+		`pr_service_team_open_reviews{team="frontend",tenant="default"} 0`,
+		`pr_service_store_scrape_error 0`,
+	)
+	if strings.Contains(body, `source="manual"`) {
+		t.Errorf("zero reassignments must not create a series")
+	}
+}
+
+func TestStoreCollectorReportsFailure(t *testing.T) {
+	m := NewMetrics()
+	if err := m.Register(NewStoreCollector(zap.NewNop(), &fakeStore{err: errors.New("db down")})); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	body := scrape(t, m)
+	assertContains(t, body, `pr_service_store_scrape_error 1`)
+	if strings.Contains(body, "pr_service_open_pull_requests") {
+		t.Errorf("open PR gauge must be absent when the store is unavailable")
+	}
+}
@@ -0,0 +1,24 @@
+package metrics
+
+import (
+	"pr-service/internal/domain/repository/postgres"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+func New() fx.Option {
+	return fx.Module("metrics",
+		fx.Provide(
+			NewMetrics,
+		),
+		fx.Invoke(
+			func(log *zap.Logger, m *Metrics, repo *postgres.Repository) error {
+				return m.Register(NewStoreCollector(log, repo))
+			},
+		),
+		fx.Decorate(func(log *zap.Logger) *zap.Logger {
+			return log.Named("metrics")
+		}),
+	)
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"context"
+	"pr-service/internal/domain/entities"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const storeScrapeTimeout = 2 * time.Second
+
+// Store is the part of the repository the collector reads on every scrape.
+type Store interface {
+	PoolStat() *pgxpool.Stat
+	GetLoadSnapshot(ctx context.Context) (entities.LoadSnapshot, error)
+}
+
+// storeCollector reports connection pool statistics and the current review
+// load straight from the database at scrape time.
+type storeCollector struct {
+	log   *zap.Logger
+	store Store
+
+	acquiredConns    *prometheus.Desc
+	idleConns        *prometheus.Desc
+	totalConns       *prometheus.Desc
+	maxConns         *prometheus.Desc
+	acquireCount     *prometheus.Desc
+	acquireDuration  *prometheus.Desc
+	emptyAcquires    *prometheus.Desc
+	canceledAcquires *prometheus.Desc
+
+	openPRs     *prometheus.Desc
+	teamReviews *prometheus.Desc
+	scrapeError *prometheus.Desc
+}
+
+func NewStoreCollector(log *zap.Logger, store Store) prometheus.Collector {
+	desc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, labels, nil)
+	}
+	return &storeCollector{
+		log:   log,
+		store: store,
+
+		acquiredConns:    desc("db_pool_acquired_conns", "Connections currently in use."),
+		idleConns:        desc("db_pool_idle_conns", "Idle connections in the pool."),
+		totalConns:       desc("db_pool_total_conns", "Total connections in the pool."),
+		maxConns:         desc("db_pool_max_conns", "Maximum pool size."),
+		acquireCount:     desc("db_pool_acquires_total", "Successful connection acquisitions."),
+		acquireDuration:  desc("db_pool_acquire_duration_seconds_total", "Total time spent acquiring connections."),
+		emptyAcquires:    desc("db_pool_empty_acquires_total", "Acquisitions that had to wait for a connection."),
+		canceledAcquires: desc("db_pool_canceled_acquires_total", "Acquisitions canceled by context."),
+
+		openPRs:     desc("open_pull_requests", "Pull requests in OPEN status."),
+		teamReviews: desc("team_open_reviews", "Pending reviews on OPEN pull requests by reviewer team.", "tenant", "team"),
+		scrapeError: desc("store_scrape_error", "1 if reading load from the database failed during this scrape."),
+	}
+}
+
+func (c *storeCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		c.acquiredConns, c.idleConns, c.totalConns, c.maxConns,
+		c.acquireCount, c.acquireDuration, c.emptyAcquires, c.canceledAcquires,
+		c.openPRs, c.teamReviews, c.scrapeError,
+	} {
+		ch <- d
+	}
+}
+
+func (c *storeCollector) Collect(ch chan<- prometheus.Metric) {
+	if st := c.store.PoolStat(); st != nil {
+		gauge := func(d *prometheus.Desc, v float64) {
+			ch <- prometheus.MustNewConstMetric(d, prometheus.GaugeValue, v)
+		}
+		counter := func(d *prometheus.Desc, v float64) {
+			ch <- prometheus.MustNewConstMetric(d, prometheus.CounterValue, v)
+		}
+		gauge(c.acquiredConns, float64(st.AcquiredConns()))
+		gauge(c.idleConns, float64(st.IdleConns()))
+		gauge(c.totalConns, float64(st.TotalConns()))
+		gauge(c.maxConns, float64(st.MaxConns()))
+		counter(c.acquireCount, float64(st.AcquireCount()))
+		counter(c.acquireDuration, st.AcquireDuration().Seconds())
+		counter(c.emptyAcquires, float64(st.EmptyAcquireCount()))
+		counter(c.canceledAcquires, float64(st.CanceledAcquireCount()))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeScrapeTimeout)
+	defer cancel()
+
+	snap, err := c.store.GetLoadSnapshot(ctx)
+	if err != nil {
+		c.log.Warn("failed to read load for metrics", zap.Error(err))
+		ch <- prometheus.MustNewConstMetric(c.scrapeError, prometheus.GaugeValue, 1)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.scrapeError, prometheus.GaugeValue, 0)
+	ch <- prometheus.MustNewConstMetric(c.openPRs, prometheus.GaugeValue, float64(snap.OpenPullRequests))
+	for team, n := range snap.TeamOpenReviews {
+		ch <- prometheus.MustNewConstMetric(c.teamReviews, prometheus.GaugeValue, float64(n), team.TenantID, team.TeamName)
+	}
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"pr-service/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4"
+)
+
+const apiKeyColumns = `id, name, prefix, tenant_id, user_id, created_at, last_used_at, revoked_at`
+
+func scanAPIKey(row pgx.Row) (entities.APIKey, error) {
+	var k entities.APIKey
+	err := row.Scan(&k.ID, &k.Name, &k.Prefix, &k.TenantID, &k.UserID, &k.CreatedAt, &k.LastUsedAt, &k.RevokedAt)
+	return k, err
+}
+
+func (r *Repository) CreateAPIKey(ctx context.Context, name, prefix, hash string, userID *string) (entities.APIKey, error) {
+	return scanAPIKey(r.DB.QueryRow(ctx, `
+		INSERT INTO api_keys (tenant_id, name, prefix, key_hash, user_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+apiKeyColumns,
+		tenant(ctx), name, prefix, hash, userID))
+}
+
+func (r *Repository) ListAPIKeys(ctx context.Context) ([]entities.APIKey, error) {
+	rows, err := r.DB.Query(ctx, `
+		SELECT `+apiKeyColumns+`
+		FROM api_keys
+		WHERE tenant_id=$1
+		ORDER BY id
+	`, tenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]entities.APIKey, 0)
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey returns pgx.ErrNoRows for unknown or already revoked keys.
+func (r *Repository) RevokeAPIKey(ctx context.Context, id int64) error {
+	tag, err := r.DB.Exec(ctx, `
+		UPDATE api_keys
+		SET revoked_at = NOW()
+		WHERE tenant_id=$1 AND id=$2 AND revoked_at IS NULL
+	`, tenant(ctx), id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// UseAPIKey looks up an active key by hash in any tenant and records its use.
+// last_used_at is only bumped once a minute to avoid a write on every request.
+func (r *Repository) UseAPIKey(ctx context.Context, hash string) (entities.APIKey, error) {
+	k, err := scanAPIKey(r.DB.QueryRow(ctx, `
+		SELECT `+apiKeyColumns+`
+		FROM api_keys
+		WHERE key_hash=$1 AND revoked_at IS NULL
+	`, hash))
+	if err != nil {
+		return entities.APIKey{}, err
+	}
+
+	if _, err := r.DB.Exec(ctx, `
+		UPDATE api_keys
+		SET last_used_at = NOW()
+		WHERE id=$1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+	`, k.ID); err != nil {
+		return entities.APIKey{}, err
+	}
+	return k, nil
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"pr-service/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4"
+)
+
+const unavailabilityColumns = `tenant_id, id, user_id, starts_at, ends_at, reason, reassigned_at, created_at`
+
+func scanUnavailability(row pgx.Row) (entities.Unavailability, error) {
+	var ua entities.Unavailability
+	err := row.Scan(&ua.TenantID, &ua.ID, &ua.UserID, &ua.StartsAt, &ua.EndsAt, &ua.Reason, &ua.ReassignedAt, &ua.CreatedAt)
+	return ua, err
+}
+
+func (r *Repository) CreateUnavailability(ctx context.Context, ua entities.Unavailability) (entities.Unavailability, error) {
+	return scanUnavailability(r.DB.QueryRow(ctx, `
+		INSERT INTO user_unavailability (tenant_id, user_id, starts_at, ends_at, reason)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+unavailabilityColumns,
+		tenant(ctx), ua.UserID, ua.StartsAt, ua.EndsAt, ua.Reason))
+}
+
+// UpdateUnavailability changes the period; moving its start re-arms the
+// reassignment job.
+func (r *Repository) UpdateUnavailability(ctx context.Context, ua entities.Unavailability) (entities.Unavailability, error) {
+	return scanUnavailability(r.DB.QueryRow(ctx, `
+		UPDATE user_unavailability
+		SET starts_at = $2,
+		    ends_at = $3,
+		    reason = $4,
+		    reassigned_at = CASE WHEN starts_at = $2 THEN reassigned_at END
+		WHERE tenant_id=$5 AND id=$1
+		RETURNING `+unavailabilityColumns,
+		ua.ID, ua.StartsAt, ua.EndsAt, ua.Reason, tenant(ctx)))
+}
+
+func (r *Repository) GetUnavailability(ctx context.Context, id int64) (entities.Unavailability, error) {
+	return scanUnavailability(r.DB.QueryRow(ctx, `
+		SELECT `+unavailabilityColumns+`
+		FROM user_unavailability
+		WHERE tenant_id=$1 AND id=$2
+	`, tenant(ctx), id))
+}
+
+func (r *Repository) DeleteUnavailability(ctx context.Context, id int64) error {
+	tag, err := r.DB.Exec(ctx, `DELETE FROM user_unavailability WHERE tenant_id=$1 AND id=$2`, tenant(ctx), id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *Repository) ListUnavailability(ctx context.Context, userID string) ([]entities.Unavailability, error) {
+	return r.queryUnavailability(ctx, `
+		SELECT `+unavailabilityColumns+`
+		FROM user_unavailability
+		WHERE tenant_id=$1 AND user_id=$2
+		ORDER BY starts_at
+	`, tenant(ctx), userID)
+}
+
+// ListStartedUnavailability returns periods that are in effect now and whose
+// reviews have not been handed over yet, across all tenants.
+func (r *Repository) ListStartedUnavailability(ctx context.Context, limit int) ([]entities.Unavailability, error) {
+	return r.queryUnavailability(ctx, `
+		SELECT `+unavailabilityColumns+`
+		FROM user_unavailability
+		WHERE reassigned_at IS NULL AND starts_at <= NOW() AND ends_at > NOW()
+		ORDER BY starts_at
+		LIMIT $1
+	`, limit)
+}
+
+func (r *Repository) queryUnavailability(ctx context.Context, sql string, args ...interface{}) ([]entities.Unavailability, error) {
+	rows, err := r.DB.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]entities.Unavailability, 0)
+	for rows.Next() {
+		ua, err := scanUnavailability(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, ua)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ReassignForUnavailability hands the user's OPEN reviews over to available
+// teammates (or fallback teams) and marks the period as processed. It returns
+// pgx.ErrNoRows if the period was already processed or is locked by another
+// instance.
+func (r *Repository) ReassignForUnavailability(
+	ctx context.Context,
+	ua entities.Unavailability,
+	teamName string,
+	fallbackTeams []string,
+) (reassigned int, err error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	var id int64
+	if err = tx.QueryRow(ctx, `
+		SELECT id
+		FROM user_unavailability
+		WHERE tenant_id=$1 AND id=$2 AND reassigned_at IS NULL
+		FOR UPDATE SKIP LOCKED
+	`, tenant(ctx), ua.ID).Scan(&id); err != nil {
+		return 0, err
+	}
+
+	reassigned, _, err = reassignOpenReviews(ctx, tx, reassignment{
+		teamName:      teamName,
+		userIDs:       []string{ua.UserID},
+		fallbackTeams: fallbackTeams,
+		eventType:     entities.AssignmentEventReassign,
+		reason:        "reviewer unavailable",
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = tx.Exec(ctx, `
+		UPDATE user_unavailability
+		SET reassigned_at = NOW()
+		WHERE id=$1
+	`, ua.ID); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return reassigned, nil
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"errors"
+	"math/rand"
 	"pr-service/internal/domain/entities"
 
 	"github.com/jackc/pgx/v4"
@@ -14,6 +15,7 @@ func (r *Repository) BulkDeactivateTeamUsers(
 	ctx context.Context,
 	teamName string,
 	userIDs []string,
+	fallbackTeams []string,
 ) (res entities.BulkDeactivateResult, err error) {
 	res.TeamName = teamName
 
@@ -34,55 +36,58 @@ func (r *Repository) BulkDeactivateTeamUsers(
 	cmd, err := tx.Exec(ctx, `
 		UPDATE users
 		SET is_active = FALSE
-		WHERE team_name = $1 AND user_id = ANY($2)
-	`, teamName, userIDs)
+		WHERE tenant_id = $3 AND team_name = $1 AND user_id = ANY($2)
+	`, teamName, userIDs, tenant(ctx))
 	if err != nil {
 		return res, err
 	}
 	res.Deactivated = int(cmd.RowsAffected())
 
-	rows, err := tx.Query(ctx, `
-		SELECT user_id
-		FROM users
-		WHERE team_name = $1 AND is_active = TRUE
-	`, teamName)
+	res.ReassignedCount, res.FallbackCount, err = reassignOpenReviews(ctx, tx, reassignment{
+		teamName:      teamName,
+		userIDs:       userIDs,
+		fallbackTeams: fallbackTeams,
+		eventType:     entities.AssignmentEventDeactivate,
+		reason:        "reviewer deactivated",
+	})
 	if err != nil {
 		return res, err
 	}
-	defer rows.Close()
 
-	activeCandidates := make([]string, 0)
-	for rows.Next() {
-		var id string
-		if err = rows.Scan(&id); err != nil {
-			return res, err
-		}
-		activeCandidates = append(activeCandidates, id)
-	}
-	if err = rows.Err(); err != nil {
-		return res, err
-	}
-	if len(activeCandidates) == 0 {
-		err = ErrNoReplacementCandidate
+	if err = tx.Commit(ctx); err != nil {
 		return res, err
 	}
+	return res, nil
+}
 
+type reassignment struct {
+	teamName      string
+	userIDs       []string
+	fallbackTeams []string
+	eventType     string
+	reason        string
+}
+
+// reassignOpenReviews moves the OPEN reviews of ra.userIDs to the least loaded
+// available members of the team, then of its fallback teams. The reviewers
+// being replaced must already be excluded from the candidate pools.
+func reassignOpenReviews(ctx context.Context, tx pgx.Tx, ra reassignment) (reassigned, fallback int, err error) {
 	type assignment struct {
 		PRID        string
 		AuthorID    string
 		OldReviewer string
 	}
 
-	rows, err = tx.Query(ctx, `
+	rows, err := tx.Query(ctx, `
 		SELECT pr.pull_request_id, pr.author_id, rpr.reviewer_id
 		FROM pull_request_reviewers rpr
-		JOIN pull_requests pr ON pr.pull_request_id = rpr.pull_request_id
-		WHERE pr.status = 'OPEN'
+		JOIN pull_requests pr ON pr.tenant_id = rpr.tenant_id AND pr.pull_request_id = rpr.pull_request_id
+		WHERE rpr.tenant_id = $2 AND pr.status = 'OPEN'
 		  AND rpr.reviewer_id = ANY($1)
 		ORDER BY pr.pull_request_id
-	`, userIDs)
+	`, ra.userIDs, tenant(ctx))
 	if err != nil {
-		return res, err
+		return 0, 0, err
 	}
 
 	assignments := make([]assignment, 0)
@@ -90,22 +95,43 @@ func (r *Repository) BulkDeactivateTeamUsers(
 	for rows.Next() {
 		var a assignment
 		if err = rows.Scan(&a.PRID, &a.AuthorID, &a.OldReviewer); err != nil {
-			return res, err
+			rows.Close()
+			return 0, 0, err
 		}
 		assignments = append(assignments, a)
 		prIDsSet[a.PRID] = struct{}{}
 	}
+	rows.Close()
 	if err = rows.Err(); err != nil {
-		return res, err
+		return 0, 0, err
 	}
-	rows.Close()
 
 	if len(assignments) == 0 {
+		return 0, 0, nil
+	}
 
-		if err = tx.Commit(ctx); err != nil {
-			return res, err
+	// pools[0] is the team itself, the rest are its fallback teams in order.
+	pools := make([][]string, 0, 1+len(ra.fallbackTeams))
+	allCandidates := make([]string, 0)
+	capacity := make(map[string]int)
+	for _, team := range append([]string{ra.teamName}, ra.fallbackTeams...) {
+		pool, err := activeTeamCandidates(ctx, tx, team, capacity)
+		if err != nil {
+			return 0, 0, err
 		}
-		return res, nil
+		rand.Shuffle(len(pool), func(i, j int) {
+			pool[i], pool[j] = pool[j], pool[i]
+		})
+		pools = append(pools, pool)
+		allCandidates = append(allCandidates, pool...)
+	}
+	if len(allCandidates) == 0 {
+		return 0, 0, ErrNoReplacementCandidate
+	}
+
+	load, err := queryOpenReviewLoad(ctx, tx, allCandidates)
+	if err != nil {
+		return 0, 0, err
 	}
 
 	prIDs := make([]string, 0, len(prIDsSet))
@@ -116,10 +142,10 @@ func (r *Repository) BulkDeactivateTeamUsers(
 	rows, err = tx.Query(ctx, `
 		SELECT pull_request_id, reviewer_id
 		FROM pull_request_reviewers
-		WHERE pull_request_id = ANY($1)
-	`, prIDs)
+		WHERE tenant_id = $2 AND pull_request_id = ANY($1)
+	`, prIDs, tenant(ctx))
 	if err != nil {
-		return res, err
+		return 0, 0, err
 	}
 	defer rows.Close()
 
@@ -127,7 +153,7 @@ func (r *Repository) BulkDeactivateTeamUsers(
 	for rows.Next() {
 		var prID, reviewerID string
 		if err = rows.Scan(&prID, &reviewerID); err != nil {
-			return res, err
+			return 0, 0, err
 		}
 		if _, ok := prReviewers[prID]; !ok {
 			prReviewers[prID] = make(map[string]struct{})
@@ -135,8 +161,9 @@ func (r *Repository) BulkDeactivateTeamUsers(
 		prReviewers[prID][reviewerID] = struct{}{}
 	}
 	if err = rows.Err(); err != nil {
-		return res, err
+		return 0, 0, err
 	}
+	rows.Close()
 
 	type replacement struct {
 		PRID        string
@@ -148,23 +175,42 @@ func (r *Repository) BulkDeactivateTeamUsers(
 	for _, a := range assignments {
 		reviewersForPR := prReviewers[a.PRID]
 
+		// Members under their review cap are preferred across all pools;
+		// only when there are none is a capped reviewer overloaded.
 		var chosen string
-		for _, cand := range activeCandidates {
-			if cand == a.AuthorID {
-				continue
+		for _, respectCap := range []bool{true, false} {
+			for i, pool := range pools {
+				for _, cand := range pool {
+					if cand == a.AuthorID {
+						continue
+					}
+					if _, already := reviewersForPR[cand]; already {
+						continue
+					}
+					if respectCap && capacity[cand] > 0 && load[cand] >= capacity[cand] {
+						continue
+					}
+					if chosen == "" || load[cand] < load[chosen] {
+						chosen = cand
+					}
+				}
+				if chosen != "" {
+					if i > 0 {
+						fallback++
+					}
+					break
+				}
 			}
-			if _, already := reviewersForPR[cand]; already {
-				continue
+			if chosen != "" {
+				break
 			}
-			chosen = cand
-			break
 		}
 
 		if chosen == "" {
-			err = ErrNoReplacementCandidate
-			return res, err
+			return 0, 0, ErrNoReplacementCandidate
 		}
 
+		load[chosen]++
 		reviewersForPR[chosen] = struct{}{}
 		replacements = append(replacements, replacement{
 			PRID:        a.PRID,
@@ -174,24 +220,69 @@ func (r *Repository) BulkDeactivateTeamUsers(
 	}
 
 	for _, rep := range replacements {
-		tag, execErr := tx.Exec(ctx, `
+		tag, err := tx.Exec(ctx, `
 			UPDATE pull_request_reviewers
-			SET reviewer_id = $3
-			WHERE pull_request_id = $1 AND reviewer_id = $2
-		`, rep.PRID, rep.OldReviewer, rep.NewReviewer)
-		if execErr != nil {
-			err = execErr
-			return res, err
+			SET reviewer_id = $3, state = 'PENDING', state_updated_at = NULL,
+			    assigned_at = NOW(), reminded_at = NULL, escalated_at = NULL,
+			    first_response_at = NULL, approved_at = NULL
+			WHERE tenant_id = $4 AND pull_request_id = $1 AND reviewer_id = $2
+		`, rep.PRID, rep.OldReviewer, rep.NewReviewer, tenant(ctx))
+		if err != nil {
+			return 0, 0, err
 		}
 		if tag.RowsAffected() == 0 {
-			err = pgx.ErrNoRows
-			return res, err
+			return 0, 0, pgx.ErrNoRows
+		}
+		reassigned += int(tag.RowsAffected())
+
+		if err = insertAssignmentEvent(ctx, tx, entities.AssignmentEvent{
+			PullRequestID: rep.PRID,
+			EventType:     ra.eventType,
+			OldReviewerID: rep.OldReviewer,
+			NewReviewerID: rep.NewReviewer,
+			Reason:        ra.reason,
+		}); err != nil {
+			return 0, 0, err
 		}
-		res.ReassignedCount += int(tag.RowsAffected())
 	}
 
-	if err = tx.Commit(ctx); err != nil {
-		return res, err
+	return reassigned, fallback, nil
+}
+
+// activeTeamCandidates returns active members of the team and records their
+// effective review cap (0 for unlimited) in capacity.
+func activeTeamCandidates(ctx context.Context, q querier, teamName string, capacity map[string]int) ([]string, error) {
+	rows, err := q.Query(ctx, `
+		SELECT u.user_id, COALESCE(u.max_open_reviews, ts.max_open_reviews, 0)
+		FROM users u
+		LEFT JOIN team_settings ts ON ts.tenant_id = u.tenant_id AND ts.team_name = u.team_name
+		WHERE u.tenant_id = $2 AND u.team_name = $1 AND u.is_active = TRUE
+		  AND NOT EXISTS (
+		      SELECT 1
+		      FROM user_unavailability ua
+		      WHERE ua.tenant_id = u.tenant_id AND ua.user_id = u.user_id
+		        AND NOW() >= ua.starts_at AND NOW() < ua.ends_at
+		  )
+	`, teamName, tenant(ctx))
+	if err != nil {
+		return nil, err
 	}
-	return res, nil
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var (
+			id    string
+			limit int
+		)
+		if err := rows.Scan(&id, &limit); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+		capacity[id] = limit
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
 }
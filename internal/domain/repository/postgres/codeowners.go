@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+	"pr-service/internal/domain/entities"
+)
+
+func (r *Repository) UpsertTeamCodeowners(ctx context.Context, teamName, content string) (entities.TeamCodeowners, error) {
+	co := entities.TeamCodeowners{TeamName: teamName, Content: content}
+	err := r.DB.QueryRow(ctx, `
+		INSERT INTO team_codeowners (tenant_id, team_name, content)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id, team_name) DO UPDATE
+		SET content = EXCLUDED.content,
+		    updated_at = NOW()
+		RETURNING updated_at
+	`, tenant(ctx), teamName, content).Scan(&co.UpdatedAt)
+	if err != nil {
+		return entities.TeamCodeowners{}, err
+	}
+	return co, nil
+}
+
+func (r *Repository) GetTeamCodeowners(ctx context.Context, teamName string) (entities.TeamCodeowners, error) {
+	var co entities.TeamCodeowners
+	err := r.DB.QueryRow(ctx, `
+		SELECT team_name, content, updated_at
+		FROM team_codeowners
+		WHERE tenant_id=$1 AND team_name=$2
+	`, tenant(ctx), teamName).Scan(&co.TeamName, &co.Content, &co.UpdatedAt)
+	if err != nil {
+		return entities.TeamCodeowners{}, err
+	}
+	return co, nil
+}
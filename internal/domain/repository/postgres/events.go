@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"pr-service/internal/domain/entities"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type dbtx interface {
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+}
+
+var webhookEventTypes = map[string]string{
+	entities.AssignmentEventAssign:     entities.WebhookEventReviewerAssigned,
+	entities.AssignmentEventReassign:   entities.WebhookEventReviewerReassigned,
+	entities.AssignmentEventUnassign:   entities.WebhookEventReviewerUnassigned,
+	entities.AssignmentEventDeactivate: entities.WebhookEventReviewerDeactivated,
+	entities.AssignmentEventMerge:      entities.WebhookEventPullRequestMerged,
+	entities.AssignmentEventClose:      entities.WebhookEventPullRequestClosed,
+	entities.AssignmentEventReopen:     entities.WebhookEventPullRequestReopened,
+}
+
+func insertAssignmentEvent(ctx context.Context, q dbtx, ev entities.AssignmentEvent) error {
+	ev.Actor = entities.ActorFromContext(ctx)
+	if err := q.QueryRow(ctx, `
+		INSERT INTO assignment_events (tenant_id, pull_request_id, event_type, old_reviewer_id, new_reviewer_id, reason, actor)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7)
+		RETURNING id, created_at
+	`, tenant(ctx), ev.PullRequestID, ev.EventType, ev.OldReviewerID, ev.NewReviewerID, ev.Reason, ev.Actor).
+		Scan(&ev.ID, &ev.CreatedAt); err != nil {
+		return err
+	}
+
+	return enqueueWebhookEvent(ctx, q, ev)
+}
+
+func enqueueWebhookEvent(ctx context.Context, q dbtx, ev entities.AssignmentEvent) error {
+	eventType, ok := webhookEventTypes[ev.EventType]
+	if !ok {
+		return nil
+	}
+
+	payload, err := json.Marshal(entities.WebhookPayload{
+		ID:         ev.ID,
+		TenantID:   tenant(ctx),
+		Event:      eventType,
+		OccurredAt: ev.CreatedAt,
+		Data:       ev,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(ctx, `
+		INSERT INTO webhook_outbox (webhook_id, event_type, payload)
+		SELECT id, $1, $2
+		FROM webhooks
+		WHERE tenant_id = $3 AND is_active AND (cardinality(event_types) = 0 OR $1 = ANY(event_types))
+	`, eventType, payload, tenant(ctx))
+	return err
+}
+
+func assignReviewers(ctx context.Context, q dbtx, prID string, reviewers []string, reason string) error {
+	for _, rid := range reviewers {
+		if _, err := q.Exec(ctx, `
+			INSERT INTO pull_request_reviewers (tenant_id, pull_request_id, reviewer_id)
+			VALUES ($1, $2, $3)
+		`, tenant(ctx), prID, rid); err != nil {
+			return err
+		}
+		if err := insertAssignmentEvent(ctx, q, entities.AssignmentEvent{
+			PullRequestID: prID,
+			EventType:     entities.AssignmentEventAssign,
+			NewReviewerID: rid,
+			Reason:        reason,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Repository) ListAssignmentEvents(ctx context.Context, prID string) ([]entities.AssignmentEvent, error) {
+	rows, err := r.DB.Query(ctx, `
+		SELECT id, pull_request_id, event_type,
+		       COALESCE(old_reviewer_id, ''), COALESCE(new_reviewer_id, ''),
+		       reason, actor, created_at
+		FROM assignment_events
+		WHERE tenant_id=$1 AND pull_request_id=$2
+		ORDER BY id
+	`, tenant(ctx), prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]entities.AssignmentEvent, 0)
+	for rows.Next() {
+		var ev entities.AssignmentEvent
+		if err := rows.Scan(
+			&ev.ID, &ev.PullRequestID, &ev.EventType,
+			&ev.OldReviewerID, &ev.NewReviewerID,
+			&ev.Reason, &ev.Actor, &ev.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		events = append(events, ev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
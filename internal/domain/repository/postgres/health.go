@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+)
+
+// SchemaVersion is the migration the code is written against: the number of
+// the newest file in migrations/.
+const SchemaVersion = 21
+
+var errNotConnected = errors.New("database is not connected")
+
+func (r *Repository) Ping(ctx context.Context) error {
+	if r.DB == nil {
+		return errNotConnected
+	}
+	return r.DB.Ping(ctx)
+}
+
+// MigrationVersion reads the state golang-migrate keeps in schema_migrations.
+func (r *Repository) MigrationVersion(ctx context.Context) (version int64, dirty bool, err error) {
+	if r.DB == nil {
+		return 0, false, errNotConnected
+	}
+	err = r.DB.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	return version, dirty, err
+}
@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSchemaVersionMatchesMigrations(t *testing.T) {
+	entries, err := os.ReadDir("../../../../migrations")
+	if err != nil {
+		t.Fatalf("read migrations: %v", err)
+	}
+
+	latest := 0
+	for _, e := range entries {
+		num, _, ok := strings.Cut(e.Name(), "_")
+		if !ok || !strings.HasSuffix(e.Name(), ".up.sql") {
+			continue
+		}
+		v, err := strconv.Atoi(num)
+		if err != nil {
+			t.Fatalf("migration %s: %v", e.Name(), err)
+		}
+		latest = max(latest, v)
+	}
+	if latest != SchemaVersion {
+		t.Fatalf("SchemaVersion = %d, newest migration is %d", SchemaVersion, latest)
+	}
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"pr-service/internal/domain/entities"
+)
+
+var ErrIdentityLinked = errors.New("external login is linked to another user")
+
+// LinkIdentity is idempotent for the same user. A login that already belongs
+// to someone else is left alone and ErrIdentityLinked is returned.
+func (r *Repository) LinkIdentity(ctx context.Context, identity entities.UserIdentity) error {
+	var userID string
+	err := r.DB.QueryRow(ctx, `
+		INSERT INTO user_identities (tenant_id, provider, external_login, user_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tenant_id, provider, external_login) DO UPDATE
+		SET user_id = user_identities.user_id
+		RETURNING user_id
+	`, tenant(ctx), identity.Provider, identity.Login, identity.UserID).Scan(&userID)
+	if err != nil {
+		return err
+	}
+	if userID != identity.UserID {
+		return ErrIdentityLinked
+	}
+	return nil
+}
+
+func (r *Repository) GetUserIDByIdentity(ctx context.Context, provider, login string) (string, error) {
+	var userID string
+	err := r.DB.QueryRow(ctx, `
+		SELECT user_id
+		FROM user_identities
+		WHERE tenant_id=$1 AND provider=$2 AND external_login=$3
+	`, tenant(ctx), provider, login).Scan(&userID)
+	if err != nil {
+		return "", err
+	}
+	return userID, nil
+}
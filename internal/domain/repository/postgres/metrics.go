@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"pr-service/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// PoolStat returns nil until the pool is connected.
+func (r *Repository) PoolStat() *pgxpool.Stat {
+	if r.pool == nil {
+		return nil
+	}
+	return r.pool.Stat()
+}
+
+// GetLoadSnapshot counts OPEN pull requests and their pending reviews per
+// reviewer team across all tenants; every team is listed, idle ones with zero.
+func (r *Repository) GetLoadSnapshot(ctx context.Context) (entities.LoadSnapshot, error) {
+	snap := entities.LoadSnapshot{TeamOpenReviews: make(map[entities.TeamKey]int)}
+
+	if err := r.DB.QueryRow(ctx, `
+		SELECT COUNT(*) FROM pull_requests WHERE status = 'OPEN'
+	`).Scan(&snap.OpenPullRequests); err != nil {
+		return snap, err
+	}
+
+	rows, err := r.DB.Query(ctx, `
+		SELECT t.tenant_id, t.team_name, COUNT(l.pull_request_id)
+		FROM teams t
+		LEFT JOIN (
+			SELECT u.tenant_id, u.team_name, rpr.pull_request_id
+			FROM pull_request_reviewers rpr
+			JOIN pull_requests p ON p.tenant_id = rpr.tenant_id AND p.pull_request_id = rpr.pull_request_id
+			JOIN users u ON u.tenant_id = rpr.tenant_id AND u.user_id = rpr.reviewer_id
+			WHERE p.status = 'OPEN' AND rpr.state = 'PENDING'
+		) l ON l.tenant_id = t.tenant_id AND l.team_name = t.team_name
+		GROUP BY t.tenant_id, t.team_name
+	`)
+	if err != nil {
+		return snap, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			team entities.TeamKey
+			n    int
+		)
+		if err := rows.Scan(&team.TenantID, &team.TeamName, &n); err != nil {
+			return snap, err
+		}
+		snap.TeamOpenReviews[team] = n
+	}
+	if err := rows.Err(); err != nil {
+		return snap, err
+	}
+	return snap, nil
+}
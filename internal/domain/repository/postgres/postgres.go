@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"pr-service/config"
+	"pr-service/internal/domain/entities"
 	"time"
 
 	"github.com/jackc/pgconn"
@@ -13,10 +14,11 @@ import (
 )
 
 type Repository struct {
-	ctx context.Context
-	log *zap.Logger
-	cfg *config.ConfigModel
-	DB  PgxPool
+	ctx  context.Context
+	log  *zap.Logger
+	cfg  *config.ConfigModel
+	DB   PgxPool
+	pool *pgxpool.Pool
 }
 
 type PgxPool interface {
@@ -24,9 +26,19 @@ type PgxPool interface {
 	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
 	QueryRow(context.Context, string, ...interface{}) pgx.Row
 	Begin(context.Context) (pgx.Tx, error)
+	Ping(context.Context) error
 	Close()
 }
 
+type querier interface {
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+}
+
+// tenant returns the tenant that every query of the request is scoped to.
+func tenant(ctx context.Context) string {
+	return entities.TenantFromContext(ctx)
+}
+
 func NewRepository(
 	ctx context.Context,
 	log *zap.Logger,
@@ -52,7 +64,8 @@ func (r *Repository) OnStart(_ context.Context) error {
 	for i := 0; i < 5; i++ {
 		pool, err = pgxpool.Connect(r.ctx, u)
 		if err == nil {
-			r.DB = pool
+			r.pool = pool
+			r.DB = newTracedPool(pool)
 			return nil
 		}
 
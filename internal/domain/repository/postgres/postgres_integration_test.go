@@ -5,12 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"pr-service/config"
 	"pr-service/internal/domain/entities"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"go.uber.org/zap"
 )
@@ -87,7 +91,7 @@ func TestCreateTeamAndStatsIntegration(t *testing.T) {
 		t.Fatalf("expected %d members, got %d", len(team.Members), len(got.Members))
 	}
 
-	if _, err := repo.GetAssignmentsStats(ctx); err != nil {
+	if _, err := repo.GetAssignmentsStats(ctx, entities.AssignmentsStatsFilter{}); err != nil {
 		t.Fatalf("GetAssignmentsStats: %v", err)
 	}
 }
@@ -218,7 +222,7 @@ func TestPullRequestLifecycleIntegration(t *testing.T) {
 	}
 
 	reviewers := []string{r1, r2}
-	if err := repo.CreatePullRequest(ctx, pr, reviewers); err != nil {
+	if err := repo.CreatePullRequest(ctx, pr, reviewers, nil); err != nil {
 		t.Fatalf("CreatePullRequest: %v", err)
 	}
 
@@ -236,7 +240,7 @@ func TestPullRequestLifecycleIntegration(t *testing.T) {
 		t.Fatalf("expected non-zero created_at")
 	}
 
-	merged, mergedReviewers, err := repo.MarkPullRequestMerged(ctx, prID)
+	merged, mergedReviewers, err := repo.MarkPullRequestMerged(ctx, prID, nil)
 	if err != nil {
 		t.Fatalf("MarkPullRequestMerged: %v", err)
 	}
@@ -250,7 +254,7 @@ func TestPullRequestLifecycleIntegration(t *testing.T) {
 		t.Fatalf("expected reviewers %v after merge, got %v", reviewers, mergedReviewers)
 	}
 
-	list, err := repo.ListPullRequestsByReviewer(ctx, r1)
+	list, err := repo.ListPullRequestsByReviewer(ctx, r1, false)
 	if err != nil {
 		t.Fatalf("ListPullRequestsByReviewer: %v", err)
 	}
@@ -290,11 +294,11 @@ func TestReplaceReviewerIntegration(t *testing.T) {
 		Status:          "OPEN",
 	}
 
-	if err := repo.CreatePullRequest(ctx, pr, []string{oldRev}); err != nil {
+	if err := repo.CreatePullRequest(ctx, pr, []string{oldRev}, nil); err != nil {
 		t.Fatalf("CreatePullRequest: %v", err)
 	}
 
-	if err := repo.ReplaceReviewer(ctx, prID, oldRev, newRev); err != nil {
+	if _, err := repo.ReplaceReviewer(ctx, prID, oldRev, newRev, "test", nil); err != nil {
 		t.Fatalf("ReplaceReviewer: %v", err)
 	}
 
@@ -306,6 +310,22 @@ func TestReplaceReviewerIntegration(t *testing.T) {
 	if len(reviewers) != 1 || reviewers[0] != newRev {
 		t.Fatalf("expected reviewers [%s], got %v", newRev, reviewers)
 	}
+
+	events, err := repo.ListAssignmentEvents(ctx, prID)
+	if err != nil {
+		t.Fatalf("ListAssignmentEvents: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events (assign, reassign), got %+v", events)
+	}
+	if events[0].EventType != entities.AssignmentEventAssign || events[0].NewReviewerID != oldRev {
+		t.Fatalf("unexpected assign event: %+v", events[0])
+	}
+	reassign := events[1]
+	if reassign.EventType != entities.AssignmentEventReassign ||
+		reassign.OldReviewerID != oldRev || reassign.NewReviewerID != newRev || reassign.Reason != "test" {
+		t.Fatalf("unexpected reassign event: %+v", reassign)
+	}
 }
 
 func TestAssignmentsStatsIntegration(t *testing.T) {
@@ -350,17 +370,17 @@ func TestAssignmentsStatsIntegration(t *testing.T) {
 		Status:          "OPEN",
 	}
 
-	if err := repo.CreatePullRequest(ctx, pr1, []string{r1, r2}); err != nil {
+	if err := repo.CreatePullRequest(ctx, pr1, []string{r1, r2}, nil); err != nil {
 		t.Fatalf("CreatePullRequest(pr1): %v", err)
 	}
-	if err := repo.CreatePullRequest(ctx, pr2, []string{r1}); err != nil {
+	if err := repo.CreatePullRequest(ctx, pr2, []string{r1}, nil); err != nil {
 		t.Fatalf("CreatePullRequest(pr2): %v", err)
 	}
-	if err := repo.CreatePullRequest(ctx, pr3, []string{r1}); err != nil {
+	if err := repo.CreatePullRequest(ctx, pr3, []string{r1}, nil); err != nil {
 		t.Fatalf("CreatePullRequest(pr3): %v", err)
 	}
 
-	stats, err := repo.GetAssignmentsStats(ctx)
+	stats, err := repo.GetAssignmentsStats(ctx, entities.AssignmentsStatsFilter{})
 	if err != nil {
 		t.Fatalf("GetAssignmentsStats: %v", err)
 	}
@@ -374,6 +394,42 @@ func TestAssignmentsStatsIntegration(t *testing.T) {
 	if r2Assignments < 1 {
 		t.Fatalf("expected at least 1 assignment for %s, got %d", r2, r2Assignments)
 	}
+
+	if _, _, err := repo.MarkPullRequestMerged(ctx, pr2.PullRequestID, nil); err != nil {
+		t.Fatalf("MarkPullRequestMerged: %v", err)
+	}
+
+	stats, err = repo.GetAssignmentsStats(ctx, entities.AssignmentsStatsFilter{
+		TeamName: teamName,
+		Status:   entities.PullRequestStatusOpen,
+		From:     time.Now().Add(-time.Hour),
+		To:       time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("GetAssignmentsStats(filtered): %v", err)
+	}
+	if len(stats) != 3 {
+		t.Fatalf("expected all 3 active members of %s, got %+v", teamName, stats)
+	}
+	if got := getAssignmentsFor(stats, r1); got != 2 {
+		t.Fatalf("expected 2 open assignments for %s, got %d", r1, got)
+	}
+	if got := getAssignmentsFor(stats, authorID); got != 0 {
+		t.Fatalf("expected zero assignments for %s, got %d", authorID, got)
+	}
+
+	stats, err = repo.GetAssignmentsStats(ctx, entities.AssignmentsStatsFilter{
+		TeamName: teamName,
+		From:     time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("GetAssignmentsStats(future): %v", err)
+	}
+	for _, s := range stats {
+		if s.Assignments != 0 {
+			t.Fatalf("expected no assignments after window start, got %+v", s)
+		}
+	}
 }
 
 func TestBulkDeactivateTeamUsersIntegration_Success(t *testing.T) {
@@ -414,14 +470,14 @@ func TestBulkDeactivateTeamUsersIntegration_Success(t *testing.T) {
 		Status:          "OPEN",
 	}
 
-	if err := repo.CreatePullRequest(ctx, pr1, []string{r1, r2}); err != nil {
+	if err := repo.CreatePullRequest(ctx, pr1, []string{r1, r2}, nil); err != nil {
 		t.Fatalf("CreatePullRequest(pr1): %v", err)
 	}
-	if err := repo.CreatePullRequest(ctx, pr2, []string{r1, r3}); err != nil {
+	if err := repo.CreatePullRequest(ctx, pr2, []string{r1, r3}, nil); err != nil {
 		t.Fatalf("CreatePullRequest(pr2): %v", err)
 	}
 
-	res, err := repo.BulkDeactivateTeamUsers(ctx, teamName, []string{r1})
+	res, err := repo.BulkDeactivateTeamUsers(ctx, teamName, []string{r1}, nil)
 	if err != nil {
 		t.Fatalf("BulkDeactivateTeamUsers: %v", err)
 	}
@@ -493,11 +549,11 @@ func TestBulkDeactivateTeamUsersIntegration_NoReplacementCandidate(t *testing.T)
 		Status:          "OPEN",
 	}
 
-	if err := repo.CreatePullRequest(ctx, pr, []string{reviewerID}); err != nil {
+	if err := repo.CreatePullRequest(ctx, pr, []string{reviewerID}, nil); err != nil {
 		t.Fatalf("CreatePullRequest: %v", err)
 	}
 
-	res, err := repo.BulkDeactivateTeamUsers(ctx, teamName, []string{reviewerID})
+	res, err := repo.BulkDeactivateTeamUsers(ctx, teamName, []string{reviewerID}, nil)
 	if !errors.Is(err, ErrNoReplacementCandidate) {
 		t.Fatalf("expected ErrNoReplacementCandidate, got res=%+v err=%v", res, err)
 	}
@@ -519,6 +575,1162 @@ func TestBulkDeactivateTeamUsersIntegration_NoReplacementCandidate(t *testing.T)
 	}
 }
 
+func TestBulkDeactivateTeamUsersIntegration_FallbackTeam(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	ts := time.Now().UnixNano()
+	teamName := fmt.Sprintf("int_team_bulk_fb_%d", ts)
+	fallbackTeam := teamName + "_fallback"
+	authorID := fmt.Sprintf("%s_author", teamName)
+	reviewerID := fmt.Sprintf("%s_r1", teamName)
+	fallbackID := fmt.Sprintf("%s_f1", teamName)
+
+	if err := repo.CreateTeam(ctx, entities.Team{
+		TeamName: teamName,
+		Members: []entities.TeamMember{
+			{UserID: authorID, Username: "Author", IsActive: true},
+			{UserID: reviewerID, Username: "Reviewer", IsActive: true},
+		},
+	}); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+	if err := repo.CreateTeam(ctx, entities.Team{
+		TeamName: fallbackTeam,
+		Members: []entities.TeamMember{
+			{UserID: fallbackID, Username: "Fallback", IsActive: true},
+		},
+	}); err != nil {
+		t.Fatalf("CreateTeam(fallback): %v", err)
+	}
+
+	prID := fmt.Sprintf("int_bulk_fb_pr_%d", ts)
+	if err := repo.CreatePullRequest(ctx, entities.PullRequest{
+		PullRequestID:   prID,
+		PullRequestName: "Bulk fallback PR",
+		AuthorID:        authorID,
+		Status:          "OPEN",
+	}, []string{reviewerID}, nil); err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+
+	res, err := repo.BulkDeactivateTeamUsers(ctx, teamName, []string{reviewerID}, []string{fallbackTeam})
+	if err != nil {
+		t.Fatalf("BulkDeactivateTeamUsers: %v", err)
+	}
+	if res.ReassignedCount != 1 || res.FallbackCount != 1 {
+		t.Fatalf("expected 1 reassignment to fallback team, got %+v", res)
+	}
+
+	got, reviewers, err := repo.GetPullRequest(ctx, prID)
+	if err != nil {
+		t.Fatalf("GetPullRequest: %v", err)
+	}
+	if len(reviewers) != 1 || reviewers[0] != fallbackID {
+		t.Fatalf("expected reviewers [%s], got %v", fallbackID, reviewers)
+	}
+	if len(got.FallbackReviewers) != 1 || got.Reviews[0].FallbackTeam != fallbackTeam {
+		t.Fatalf("expected reviewer to be marked as fallback, got %+v", got.Reviews)
+	}
+}
+
+func TestReviewCapacityIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	ts := time.Now().UnixNano()
+	teamName := fmt.Sprintf("int_team_capacity_%d", ts)
+	authorID := teamName + "_author"
+	busyID := teamName + "_busy"
+	freeID := teamName + "_free"
+
+	if err := repo.CreateTeam(ctx, entities.Team{
+		TeamName: teamName,
+		Members: []entities.TeamMember{
+			{UserID: authorID, Username: "Author", IsActive: true},
+			{UserID: busyID, Username: "Busy", IsActive: true},
+			{UserID: freeID, Username: "Free", IsActive: true},
+		},
+	}); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+	if err := repo.UpsertTeamSettings(ctx, entities.TeamSettings{
+		TeamName:           teamName,
+		AssignmentStrategy: entities.DefaultAssignmentStrategy,
+		ReviewersCount:     entities.DefaultReviewersCount,
+		MaxOpenReviews:     1,
+	}); err != nil {
+		t.Fatalf("UpsertTeamSettings: %v", err)
+	}
+
+	if err := repo.CreatePullRequest(ctx, entities.PullRequest{
+		PullRequestID:   fmt.Sprintf("int_capacity_pr_%d", ts),
+		PullRequestName: "Capacity PR",
+		AuthorID:        authorID,
+		Status:          entities.PullRequestStatusOpen,
+	}, []string{busyID}, nil); err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+
+	candidates, err := repo.ListTeamActiveUsersExcept(ctx, teamName, authorID)
+	if err != nil {
+		t.Fatalf("ListTeamActiveUsersExcept: %v", err)
+	}
+	if ids := usersToIDs(candidates); len(ids) != 1 || ids[0] != freeID {
+		t.Fatalf("expected only %s under capacity, got %v", freeID, ids)
+	}
+
+	capacity, err := repo.GetReviewCapacity(ctx, busyID)
+	if err != nil {
+		t.Fatalf("GetReviewCapacity: %v", err)
+	}
+	if capacity.OpenReviews != 1 || capacity.MaxOpenReviews != 1 || !capacity.AtCapacity {
+		t.Fatalf("unexpected capacity %+v", capacity)
+	}
+
+	override := 2
+	if err := repo.SetUserMaxOpenReviews(ctx, busyID, &override); err != nil {
+		t.Fatalf("SetUserMaxOpenReviews: %v", err)
+	}
+	candidates, err = repo.ListTeamActiveUsersExcept(ctx, teamName, authorID)
+	if err != nil {
+		t.Fatalf("ListTeamActiveUsersExcept(after override): %v", err)
+	}
+	if len(candidates) != 2 {
+		t.Fatalf("expected override to make %s eligible again, got %v", busyID, usersToIDs(candidates))
+	}
+}
+
+func TestUnavailabilityIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	ts := time.Now().UnixNano()
+	teamName := fmt.Sprintf("int_team_unavailable_%d", ts)
+	authorID := teamName + "_author"
+	awayID := teamName + "_away"
+	otherID := teamName + "_other"
+
+	if err := repo.CreateTeam(ctx, entities.Team{
+		TeamName: teamName,
+		Members: []entities.TeamMember{
+			{UserID: authorID, Username: "Author", IsActive: true},
+			{UserID: awayID, Username: "Away", IsActive: true},
+			{UserID: otherID, Username: "Other", IsActive: true},
+		},
+	}); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+
+	prID := fmt.Sprintf("int_unavailable_pr_%d", ts)
+	if err := repo.CreatePullRequest(ctx, entities.PullRequest{
+		PullRequestID:   prID,
+		PullRequestName: "Unavailable PR",
+		AuthorID:        authorID,
+		Status:          entities.PullRequestStatusOpen,
+	}, []string{awayID}, nil); err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+
+	ua, err := repo.CreateUnavailability(ctx, entities.Unavailability{
+		UserID:   awayID,
+		StartsAt: time.Now().Add(-time.Minute),
+		EndsAt:   time.Now().Add(time.Hour),
+		Reason:   "vacation",
+	})
+	if err != nil {
+		t.Fatalf("CreateUnavailability: %v", err)
+	}
+
+	candidates, err := repo.ListTeamActiveUsersExcept(ctx, teamName, authorID)
+	if err != nil {
+		t.Fatalf("ListTeamActiveUsersExcept: %v", err)
+	}
+	if ids := usersToIDs(candidates); len(ids) != 1 || ids[0] != otherID {
+		t.Fatalf("expected only %s to be available, got %v", otherID, ids)
+	}
+
+	n, err := repo.ReassignForUnavailability(ctx, ua, teamName, nil)
+	if err != nil {
+		t.Fatalf("ReassignForUnavailability: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 reassigned review, got %d", n)
+	}
+
+	_, reviewers, err := repo.GetPullRequest(ctx, prID)
+	if err != nil {
+		t.Fatalf("GetPullRequest: %v", err)
+	}
+	if len(reviewers) != 1 || reviewers[0] != otherID {
+		t.Fatalf("expected reviewers [%s], got %v", otherID, reviewers)
+	}
+
+	if _, err := repo.ReassignForUnavailability(ctx, ua, teamName, nil); !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("expected processed period to be skipped, got %v", err)
+	}
+}
+
+func TestUserWorkingHoursIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	teamName := fmt.Sprintf("int_team_hours_%d", time.Now().UnixNano())
+	if err := repo.CreateTeam(ctx, entities.Team{
+		TeamName: teamName,
+		Members: []entities.TeamMember{
+			{UserID: teamName + "_berlin", Username: "Berlin", IsActive: true, Timezone: "Europe/Berlin", WorkStart: "08:00", WorkEnd: "16:30"},
+			{UserID: teamName + "_default", Username: "Default", IsActive: true},
+		},
+	}); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+
+	team, err := repo.GetTeam(ctx, teamName)
+	if err != nil {
+		t.Fatalf("GetTeam: %v", err)
+	}
+	for _, m := range team.Members {
+		switch m.UserID {
+		case teamName + "_berlin":
+			if m.Timezone != "Europe/Berlin" || m.WorkStart != "08:00" || m.WorkEnd != "16:30" {
+				t.Fatalf("unexpected working hours %+v", m)
+			}
+		case teamName + "_default":
+			if m.Timezone != entities.DefaultTimezone || m.WorkStart != entities.DefaultWorkStart || m.WorkEnd != entities.DefaultWorkEnd {
+				t.Fatalf("expected default working hours, got %+v", m)
+			}
+		}
+	}
+
+	u, err := repo.GetUserByID(ctx, teamName+"_berlin")
+	if err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+	if u.Timezone != "Europe/Berlin" {
+		t.Fatalf("expected timezone on user, got %+v", u)
+	}
+}
+
+func TestGetOpenReviewLoadIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	ts := time.Now().UnixNano()
+	teamName := fmt.Sprintf("int_team_load_%d", ts)
+	authorID := fmt.Sprintf("%s_author", teamName)
+	r1 := fmt.Sprintf("%s_r1", teamName)
+	r2 := fmt.Sprintf("%s_r2", teamName)
+	r3 := fmt.Sprintf("%s_r3", teamName)
+
+	team := entities.Team{
+		TeamName: teamName,
+		Members: []entities.TeamMember{
+			{UserID: authorID, Username: "Author", IsActive: true},
+			{UserID: r1, Username: "Reviewer 1", IsActive: true},
+			{UserID: r2, Username: "Reviewer 2", IsActive: true},
+			{UserID: r3, Username: "Reviewer 3", IsActive: true},
+		},
+	}
+
+	if err := repo.CreateTeam(ctx, team); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+
+	openPR := entities.PullRequest{
+		PullRequestID:   fmt.Sprintf("int_load_open_%d", ts),
+		PullRequestName: "Load Open PR",
+		AuthorID:        authorID,
+		Status:          "OPEN",
+	}
+	mergedPR := entities.PullRequest{
+		PullRequestID:   fmt.Sprintf("int_load_merged_%d", ts),
+		PullRequestName: "Load Merged PR",
+		AuthorID:        authorID,
+		Status:          "OPEN",
+	}
+
+	if err := repo.CreatePullRequest(ctx, openPR, []string{r1, r2}, nil); err != nil {
+		t.Fatalf("CreatePullRequest(open): %v", err)
+	}
+	if err := repo.CreatePullRequest(ctx, mergedPR, []string{r1}, nil); err != nil {
+		t.Fatalf("CreatePullRequest(merged): %v", err)
+	}
+	if _, _, err := repo.MarkPullRequestMerged(ctx, mergedPR.PullRequestID, nil); err != nil {
+		t.Fatalf("MarkPullRequestMerged: %v", err)
+	}
+
+	load, err := repo.GetOpenReviewLoad(ctx, []string{r1, r2, r3})
+	if err != nil {
+		t.Fatalf("GetOpenReviewLoad: %v", err)
+	}
+	if load[r1] != 1 || load[r2] != 1 || load[r3] != 0 {
+		t.Fatalf("expected load r1=1 r2=1 r3=0, got %v", load)
+	}
+}
+
+func TestTeamSettingsIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	teamName := fmt.Sprintf("int_team_settings_%d", time.Now().UnixNano())
+	if err := repo.CreateTeam(ctx, entities.Team{TeamName: teamName}); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+
+	got, err := repo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		t.Fatalf("GetTeamSettings(default): %v", err)
+	}
+	if got.AssignmentStrategy != entities.DefaultAssignmentStrategy || got.ReviewersCount != entities.DefaultReviewersCount {
+		t.Fatalf("expected default settings, got %+v", got)
+	}
+
+	fallbackTeam := teamName + "_fallback"
+	if err := repo.CreateTeam(ctx, entities.Team{TeamName: fallbackTeam}); err != nil {
+		t.Fatalf("CreateTeam(fallback): %v", err)
+	}
+
+	want := entities.TeamSettings{
+		TeamName:           teamName,
+		AssignmentStrategy: entities.AssignmentStrategyWeighted,
+		ReviewersCount:     3,
+		FallbackTeams:      []string{fallbackTeam},
+	}
+	if err := repo.UpsertTeamSettings(ctx, want); err != nil {
+		t.Fatalf("UpsertTeamSettings: %v", err)
+	}
+
+	got, err = repo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		t.Fatalf("GetTeamSettings(after): %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected settings %+v, got %+v", want, got)
+	}
+}
+
+func TestListStaleReviewsIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	ts := time.Now().UnixNano()
+	teamName := fmt.Sprintf("int_team_sla_%d", ts)
+	authorID := teamName + "_author"
+	reviewer := teamName + "_r1"
+
+	if err := repo.CreateTeam(ctx, entities.Team{
+		TeamName: teamName,
+		Members: []entities.TeamMember{
+			{UserID: authorID, Username: "Author", IsActive: true},
+			{UserID: reviewer, Username: "Reviewer", IsActive: true},
+		},
+	}); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+	if err := repo.UpsertTeamSettings(ctx, entities.TeamSettings{
+		TeamName:             teamName,
+		AssignmentStrategy:   entities.DefaultAssignmentStrategy,
+		ReviewersCount:       entities.DefaultReviewersCount,
+		ReminderAfterMinutes: 30,
+		EscalateAfterMinutes: 60,
+	}); err != nil {
+		t.Fatalf("UpsertTeamSettings: %v", err)
+	}
+
+	prID := fmt.Sprintf("int_pr_sla_%d", ts)
+	if err := repo.CreatePullRequest(ctx, entities.PullRequest{
+		PullRequestID:   prID,
+		PullRequestName: "SLA PR",
+		AuthorID:        authorID,
+		Status:          "OPEN",
+	}, []string{reviewer}, nil); err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+
+	find := func(waiting time.Duration) (entities.StaleReview, bool) {
+		t.Helper()
+		if _, err := repo.DB.Exec(ctx, `
+			UPDATE pull_request_reviewers SET assigned_at = $2 WHERE pull_request_id = $1
+		`, prID, time.Now().Add(-waiting)); err != nil {
+			t.Fatalf("backdate assigned_at: %v", err)
+		}
+		stale, err := repo.ListStaleReviews(ctx, 10000)
+		if err != nil {
+			t.Fatalf("ListStaleReviews: %v", err)
+		}
+		for _, sr := range stale {
+			if sr.PullRequestID == prID && sr.ReviewerID == reviewer {
+				return sr, true
+			}
+		}
+		return entities.StaleReview{}, false
+	}
+
+	if _, ok := find(10 * time.Minute); ok {
+		t.Fatalf("review within SLA must not be listed")
+	}
+	sr, ok := find(45 * time.Minute)
+	if !ok || sr.Escalate {
+		t.Fatalf("expected reminder-only stale review, got %+v (found=%v)", sr, ok)
+	}
+
+	if err := repo.MarkReviewReminded(ctx, prID, reviewer); err != nil {
+		t.Fatalf("MarkReviewReminded: %v", err)
+	}
+	if _, ok := find(45 * time.Minute); ok {
+		t.Fatalf("reminded review must not be listed again before escalation")
+	}
+
+	sr, ok = find(90 * time.Minute)
+	if !ok || !sr.Escalate || sr.EscalateAfter != 60 {
+		t.Fatalf("expected review due for escalation, got %+v (found=%v)", sr, ok)
+	}
+
+	if err := repo.MarkEscalationAttempted(ctx, prID, reviewer); err != nil {
+		t.Fatalf("MarkEscalationAttempted: %v", err)
+	}
+	if _, ok := find(90 * time.Minute); ok {
+		t.Fatalf("failed escalation must wait another period before retry")
+	}
+}
+
+func TestReviewTimingsIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	ts := time.Now().UnixNano()
+	teamName := fmt.Sprintf("int_team_timings_%d", ts)
+	authorID := teamName + "_author"
+	r1 := teamName + "_r1"
+	r2 := teamName + "_r2"
+
+	if err := repo.CreateTeam(ctx, entities.Team{
+		TeamName: teamName,
+		Members: []entities.TeamMember{
+			{UserID: authorID, Username: "Author", IsActive: true},
+			{UserID: r1, Username: "Reviewer 1", IsActive: true},
+			{UserID: r2, Username: "Reviewer 2", IsActive: true},
+		},
+	}); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+	if err := repo.UpsertTeamSettings(ctx, entities.TeamSettings{
+		TeamName:             teamName,
+		AssignmentStrategy:   entities.DefaultAssignmentStrategy,
+		ReviewersCount:       entities.DefaultReviewersCount,
+		ReminderAfterMinutes: 30,
+	}); err != nil {
+		t.Fatalf("UpsertTeamSettings: %v", err)
+	}
+
+	prID := fmt.Sprintf("int_pr_timings_%d", ts)
+	if err := repo.CreatePullRequest(ctx, entities.PullRequest{
+		PullRequestID:   prID,
+		PullRequestName: "Timings PR",
+		AuthorID:        authorID,
+		Status:          "OPEN",
+	}, []string{r1, r2}, nil); err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+
+	if err := repo.SetReviewState(ctx, prID, r1, entities.ReviewStateCommented); err != nil {
+		t.Fatalf("SetReviewState(commented): %v", err)
+	}
+	if err := repo.SetReviewState(ctx, prID, r1, entities.ReviewStateApproved); err != nil {
+		t.Fatalf("SetReviewState(approved): %v", err)
+	}
+
+	timings, err := repo.ListReviewTimings(ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Hour), teamName)
+	if err != nil {
+		t.Fatalf("ListReviewTimings: %v", err)
+	}
+	if len(timings) != 2 {
+		t.Fatalf("expected 2 assignments, got %+v", timings)
+	}
+	for _, rt := range timings {
+		switch rt.ReviewerID {
+		case r1:
+			if rt.FirstResponseAt == nil || rt.ApprovedAt == nil || rt.ApprovedAt.Before(*rt.FirstResponseAt) {
+				t.Fatalf("expected first response before approval, got %+v", rt)
+			}
+		case r2:
+			if rt.FirstResponseAt != nil || rt.ApprovedAt != nil {
+				t.Fatalf("expected no response from %s, got %+v", r2, rt)
+			}
+		}
+	}
+
+	if _, err := repo.DB.Exec(ctx, `
+		UPDATE pull_request_reviewers SET assigned_at = NOW() - INTERVAL '45 minutes'
+		WHERE pull_request_id = $1
+	`, prID); err != nil {
+		t.Fatalf("backdate assigned_at: %v", err)
+	}
+
+	breaches, err := repo.ListSLABreaches(ctx, teamName)
+	if err != nil {
+		t.Fatalf("ListSLABreaches: %v", err)
+	}
+	if len(breaches) != 1 || breaches[0].ReviewerID != r2 || breaches[0].SLAMinutes != 30 || breaches[0].Escalated {
+		t.Fatalf("expected only the pending review of %s to breach, got %+v", r2, breaches)
+	}
+	if breaches[0].WaitingMinutes < 44 {
+		t.Fatalf("unexpected waiting minutes %d", breaches[0].WaitingMinutes)
+	}
+}
+
+func TestLoadSnapshotIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	ts := time.Now().UnixNano()
+	teamName := fmt.Sprintf("int_team_snapshot_%d", ts)
+	idleTeam := teamName + "_idle"
+	authorID := teamName + "_author"
+	reviewer := teamName + "_r1"
+
+	if err := repo.CreateTeam(ctx, entities.Team{
+		TeamName: teamName,
+		Members: []entities.TeamMember{
+			{UserID: authorID, Username: "Author", IsActive: true},
+			{UserID: reviewer, Username: "Reviewer", IsActive: true},
+		},
+	}); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+	if err := repo.CreateTeam(ctx, entities.Team{TeamName: idleTeam}); err != nil {
+		t.Fatalf("CreateTeam(idle): %v", err)
+	}
+
+	if err := repo.CreatePullRequest(ctx, entities.PullRequest{
+		PullRequestID:   fmt.Sprintf("int_pr_snapshot_%d", ts),
+		PullRequestName: "Snapshot PR",
+		AuthorID:        authorID,
+		Status:          "OPEN",
+	}, []string{reviewer}, nil); err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+
+	snap, err := repo.GetLoadSnapshot(ctx)
+	if err != nil {
+		t.Fatalf("GetLoadSnapshot: %v", err)
+	}
+	if snap.OpenPullRequests < 1 {
+		t.Fatalf("expected at least one open PR, got %d", snap.OpenPullRequests)
+	}
+	if got := snap.TeamOpenReviews[entities.TeamKey{TenantID: entities.DefaultTenant, TeamName: teamName}]; got != 1 {
+		t.Fatalf("expected 1 open review for %s, got %d", teamName, got)
+	}
+	if got, ok := snap.TeamOpenReviews[entities.TeamKey{TenantID: entities.DefaultTenant, TeamName: idleTeam}]; !ok || got != 0 {
+		t.Fatalf("expected idle team listed with zero, got %d (present=%v)", got, ok)
+	}
+}
+
+func TestAPIKeysIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	teamName := fmt.Sprintf("int_keys_%d", time.Now().UnixNano())
+	owner := teamName + "_u1"
+	if err := repo.CreateTeam(ctx, entities.Team{
+		TeamName: teamName,
+		Members:  []entities.TeamMember{{UserID: owner, Username: "Owner", IsActive: true}},
+	}); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+
+	hash := fmt.Sprintf("int_hash_%d", time.Now().UnixNano())
+	created, err := repo.CreateAPIKey(ctx, "ci", "prs_abcdef", hash, &owner)
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	if created.UserID == nil || *created.UserID != owner {
+		t.Fatalf("expected key bound to %s, got %v", owner, created.UserID)
+	}
+
+	used, err := repo.UseAPIKey(ctx, hash)
+	if err != nil {
+		t.Fatalf("UseAPIKey: %v", err)
+	}
+	if used.ID != created.ID || used.Name != "ci" {
+		t.Fatalf("unexpected key %+v", used)
+	}
+
+	keys, err := repo.ListAPIKeys(ctx)
+	if err != nil {
+		t.Fatalf("ListAPIKeys: %v", err)
+	}
+	found := false
+	for _, k := range keys {
+		if k.ID == created.ID {
+			found = true
+			if k.LastUsedAt == nil {
+				t.Fatalf("expected last_used_at to be recorded")
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("created key not listed")
+	}
+
+	if err := repo.RevokeAPIKey(ctx, created.ID); err != nil {
+		t.Fatalf("RevokeAPIKey: %v", err)
+	}
+	if err := repo.RevokeAPIKey(ctx, created.ID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("expected ErrNoRows revoking twice, got %v", err)
+	}
+	if _, err := repo.UseAPIKey(ctx, hash); !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("revoked key must not authenticate, got %v", err)
+	}
+}
+
+func TestUserRolesIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	teamName := fmt.Sprintf("int_roles_%d", time.Now().UnixNano())
+	team := entities.Team{
+		TeamName: teamName,
+		Members:  []entities.TeamMember{{UserID: teamName + "_u1", Username: "Lead", IsActive: true}},
+	}
+	if err := repo.CreateTeam(ctx, team); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+
+	ur, err := repo.GetUserRole(ctx, teamName+"_u1")
+	if err != nil {
+		t.Fatalf("GetUserRole: %v", err)
+	}
+	if ur.Role != entities.RoleDeveloper || ur.TeamName != teamName {
+		t.Fatalf("unexpected default role %+v", ur)
+	}
+
+	if _, err := repo.SetUserRole(ctx, teamName+"_u1", entities.RoleTeamLead); err != nil {
+		t.Fatalf("SetUserRole: %v", err)
+	}
+	// Adding the user to another team upserts them and must keep the role.
+	team.TeamName = teamName + "_moved"
+	if err := repo.CreateTeam(ctx, team); err != nil {
+		t.Fatalf("CreateTeam(moved): %v", err)
+	}
+	ur, err = repo.GetUserRole(ctx, teamName+"_u1")
+	if err != nil {
+		t.Fatalf("GetUserRole(after): %v", err)
+	}
+	if ur.Role != entities.RoleTeamLead || ur.TeamName != team.TeamName {
+		t.Fatalf("unexpected role after move %+v", ur)
+	}
+
+	if _, err := repo.SetUserRole(ctx, teamName+"_missing", entities.RoleAdmin); !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("expected ErrNoRows for unknown user, got %v", err)
+	}
+}
+
+func TestRotationCursorIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	ts := time.Now().UnixNano()
+	teamName := fmt.Sprintf("int_team_rotation_%d", ts)
+	authorID := teamName + "_author"
+	r1 := teamName + "_r1"
+	if err := repo.CreateTeam(ctx, entities.Team{
+		TeamName: teamName,
+		Members: []entities.TeamMember{
+			{UserID: authorID, Username: "Author", IsActive: true},
+			{UserID: r1, Username: "Reviewer", IsActive: true},
+		},
+	}); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+
+	if cursor, err := repo.GetRotationCursor(ctx, teamName); err != nil || cursor != "" {
+		t.Fatalf("GetRotationCursor(new team) = %q, %v", cursor, err)
+	}
+
+	seen := make([]string, 0, 2)
+	for i, next := range []string{"u1", "u2"} {
+		err := repo.CreatePullRequest(ctx, entities.PullRequest{
+			PullRequestID:   fmt.Sprintf("int_pr_rotation_%d_%d", ts, i),
+			PullRequestName: "Rotation PR",
+			AuthorID:        authorID,
+			Status:          "OPEN",
+		}, nil, &entities.Rotation{
+			Teams: []string{teamName},
+			Pick: func(cursors map[string]string) ([]string, map[string]string) {
+				seen = append(seen, cursors[teamName])
+				return []string{r1}, map[string]string{teamName: next}
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreatePullRequest(%d): %v", i, err)
+		}
+	}
+
+	if len(seen) != 2 || seen[0] != "" || seen[1] != "u1" {
+		t.Fatalf("expected cursors [\"\" u1], got %q", seen)
+	}
+	if cursor, err := repo.GetRotationCursor(ctx, teamName); err != nil || cursor != "u2" {
+		t.Fatalf("GetRotationCursor = %q, %v", cursor, err)
+	}
+	// The reviewers come from the rotation, not from the provisional list.
+	if _, reviewers, err := repo.GetPullRequest(ctx, fmt.Sprintf("int_pr_rotation_%d_0", ts)); err != nil || !reflect.DeepEqual(reviewers, []string{r1}) {
+		t.Fatalf("GetPullRequest reviewers = %v, %v", reviewers, err)
+	}
+}
+
+// Concurrent creates of one team all start from a stale cursor, as if they
+// had picked at the same time; each must still get the next reviewers in
+// rotation order.
+func TestRotationConcurrentCreatesIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	ts := time.Now().UnixNano()
+	teamName := fmt.Sprintf("int_team_rotation_conc_%d", ts)
+	authorID := teamName + "_author"
+	ids := []string{teamName + "_r1", teamName + "_r2", teamName + "_r3", teamName + "_r4"}
+	members := []entities.TeamMember{{UserID: authorID, Username: "Author", IsActive: true}}
+	for _, id := range ids {
+		members = append(members, entities.TeamMember{UserID: id, Username: id, IsActive: true})
+	}
+	if err := repo.CreateTeam(ctx, entities.Team{TeamName: teamName, Members: members}); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+
+	// Pick mirrors round_robin with two reviewers per PR.
+	pick := func(cursors map[string]string) ([]string, map[string]string) {
+		start := 0
+		for i, id := range ids {
+			if id == cursors[teamName] {
+				start = i + 1
+			}
+		}
+		picked := []string{ids[start%len(ids)], ids[(start+1)%len(ids)]}
+		return picked, map[string]string{teamName: picked[1]}
+	}
+
+	const creates = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, creates)
+	for i := 0; i < creates; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- repo.CreatePullRequest(ctx, entities.PullRequest{
+				PullRequestID:   fmt.Sprintf("int_pr_rotation_conc_%d_%d", ts, i),
+				PullRequestName: "Rotation PR",
+				AuthorID:        authorID,
+				Status:          "OPEN",
+			}, ids[:2], &entities.Rotation{Teams: []string{teamName}, Pick: pick})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("CreatePullRequest: %v", err)
+		}
+	}
+
+	pairs := make(map[string]int)
+	for i := 0; i < creates; i++ {
+		_, reviewers, err := repo.GetPullRequest(ctx, fmt.Sprintf("int_pr_rotation_conc_%d_%d", ts, i))
+		if err != nil {
+			t.Fatalf("GetPullRequest(%d): %v", i, err)
+		}
+		pairs[strings.Join(reviewers, ",")]++
+	}
+	want := map[string]int{
+		ids[0] + "," + ids[1]: creates / 2,
+		ids[2] + "," + ids[3]: creates / 2,
+	}
+	if !reflect.DeepEqual(pairs, want) {
+		t.Fatalf("reviewer pairs = %v, want %v", pairs, want)
+	}
+	if cursor, err := repo.GetRotationCursor(ctx, teamName); err != nil || cursor != ids[3] {
+		t.Fatalf("GetRotationCursor = %q, %v", cursor, err)
+	}
+}
+
+func TestReviewStateIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	ts := time.Now().UnixNano()
+	teamName := fmt.Sprintf("int_team_review_%d", ts)
+	authorID := fmt.Sprintf("%s_author", teamName)
+	r1 := fmt.Sprintf("%s_r1", teamName)
+	r2 := fmt.Sprintf("%s_r2", teamName)
+
+	team := entities.Team{
+		TeamName: teamName,
+		Members: []entities.TeamMember{
+			{UserID: authorID, Username: "Author", IsActive: true},
+			{UserID: r1, Username: "Reviewer 1", IsActive: true},
+			{UserID: r2, Username: "Reviewer 2", IsActive: true},
+		},
+	}
+
+	if err := repo.CreateTeam(ctx, team); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+
+	pr := entities.PullRequest{
+		PullRequestID:   fmt.Sprintf("int_review_pr_%d", ts),
+		PullRequestName: "Review PR",
+		AuthorID:        authorID,
+		Status:          "OPEN",
+	}
+	if err := repo.CreatePullRequest(ctx, pr, []string{r1, r2}, nil); err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+
+	if err := repo.SetReviewState(ctx, pr.PullRequestID, r1, entities.ReviewStateApproved); err != nil {
+		t.Fatalf("SetReviewState: %v", err)
+	}
+	if err := repo.SetReviewState(ctx, pr.PullRequestID, authorID, entities.ReviewStateApproved); err == nil {
+		t.Fatalf("expected error when setting state for non-assigned reviewer")
+	}
+
+	got, _, err := repo.GetPullRequest(ctx, pr.PullRequestID)
+	if err != nil {
+		t.Fatalf("GetPullRequest: %v", err)
+	}
+	states := make(map[string]string, len(got.Reviews))
+	for _, rs := range got.Reviews {
+		states[rs.ReviewerID] = rs.State
+	}
+	if states[r1] != entities.ReviewStateApproved || states[r2] != entities.ReviewStatePending {
+		t.Fatalf("unexpected review states: %v", states)
+	}
+
+	pending, err := repo.ListPullRequestsByReviewer(ctx, r1, true)
+	if err != nil {
+		t.Fatalf("ListPullRequestsByReviewer(r1, pending): %v", err)
+	}
+	if containsPR(pending, pr.PullRequestID) {
+		t.Fatalf("expected PR %s to be filtered out for %s after approval", pr.PullRequestID, r1)
+	}
+
+	pending, err = repo.ListPullRequestsByReviewer(ctx, r2, true)
+	if err != nil {
+		t.Fatalf("ListPullRequestsByReviewer(r2, pending): %v", err)
+	}
+	if !containsPR(pending, pr.PullRequestID) {
+		t.Fatalf("expected PR %s to be pending for %s", pr.PullRequestID, r2)
+	}
+}
+
+func TestMergeCheckIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	ts := time.Now().UnixNano()
+	teamName := fmt.Sprintf("int_team_merge_check_%d", ts)
+	authorID := teamName + "_author"
+	r1 := teamName + "_r1"
+	if err := repo.CreateTeam(ctx, entities.Team{
+		TeamName: teamName,
+		Members: []entities.TeamMember{
+			{UserID: authorID, Username: "Author", IsActive: true},
+			{UserID: r1, Username: "Reviewer", IsActive: true},
+		},
+	}); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+
+	prID := fmt.Sprintf("int_pr_merge_check_%d", ts)
+	if err := repo.CreatePullRequest(ctx, entities.PullRequest{
+		PullRequestID:   prID,
+		PullRequestName: "Merge check PR",
+		AuthorID:        authorID,
+		Status:          entities.PullRequestStatusOpen,
+	}, []string{r1}, nil); err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+	if err := repo.SetReviewState(ctx, prID, r1, entities.ReviewStateChangesRequested); err != nil {
+		t.Fatalf("SetReviewState: %v", err)
+	}
+
+	denied := &entities.DomainError{Code: entities.ErrorCodeNotApproved}
+	var seen []entities.ReviewerState
+	_, _, err := repo.MarkPullRequestMerged(ctx, prID, func(reviews []entities.ReviewerState) error {
+		seen = reviews
+		return denied
+	})
+	if err != denied {
+		t.Fatalf("MarkPullRequestMerged error = %v, want the check error", err)
+	}
+	if len(seen) != 1 || seen[0].ReviewerID != r1 || seen[0].State != entities.ReviewStateChangesRequested {
+		t.Fatalf("check got reviews %+v", seen)
+	}
+	if got, _, err := repo.GetPullRequest(ctx, prID); err != nil || got.Status != entities.PullRequestStatusOpen {
+		t.Fatalf("PR after denied merge: %+v, %v", got, err)
+	}
+
+	calls := 0
+	check := func([]entities.ReviewerState) error {
+		calls++
+		return nil
+	}
+	if _, _, err := repo.MarkPullRequestMerged(ctx, prID, check); err != nil {
+		t.Fatalf("MarkPullRequestMerged: %v", err)
+	}
+	// Merging again is a no-op and does not check the policy.
+	if _, _, err := repo.MarkPullRequestMerged(ctx, prID, check); err != nil {
+		t.Fatalf("MarkPullRequestMerged(again): %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("check called %d times, want 1", calls)
+	}
+}
+
+func TestDraftCloseReopenIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	ts := time.Now().UnixNano()
+	teamName := fmt.Sprintf("int_team_draft_%d", ts)
+	authorID := fmt.Sprintf("%s_author", teamName)
+	r1 := fmt.Sprintf("%s_r1", teamName)
+
+	team := entities.Team{
+		TeamName: teamName,
+		Members: []entities.TeamMember{
+			{UserID: authorID, Username: "Author", IsActive: true},
+			{UserID: r1, Username: "Reviewer 1", IsActive: true},
+		},
+	}
+
+	if err := repo.CreateTeam(ctx, team); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+
+	pr := entities.PullRequest{
+		PullRequestID:   fmt.Sprintf("int_draft_pr_%d", ts),
+		PullRequestName: "Draft PR",
+		AuthorID:        authorID,
+		Status:          entities.PullRequestStatusDraft,
+	}
+	if err := repo.CreatePullRequest(ctx, pr, nil, nil); err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+
+	if err := repo.OpenPullRequest(ctx, pr.PullRequestID, entities.PullRequestStatusDraft, []string{r1}, nil); err != nil {
+		t.Fatalf("OpenPullRequest(ready): %v", err)
+	}
+
+	closed, reviewers, err := repo.ClosePullRequest(ctx, pr.PullRequestID)
+	if err != nil {
+		t.Fatalf("ClosePullRequest: %v", err)
+	}
+	if closed.Status != entities.PullRequestStatusClosed || closed.ClosedAt == nil {
+		t.Fatalf("expected CLOSED PR with closed_at, got %+v", closed)
+	}
+	if !haveSameStrings(reviewers, []string{r1}) {
+		t.Fatalf("expected reviewers [%s], got %v", r1, reviewers)
+	}
+
+	if _, _, err := repo.ClosePullRequest(ctx, pr.PullRequestID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("expected ErrNoRows when closing CLOSED PR, got %v", err)
+	}
+	if _, _, err := repo.MarkPullRequestMerged(ctx, pr.PullRequestID, nil); !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("expected ErrNoRows when merging CLOSED PR, got %v", err)
+	}
+	if got, _, err := repo.GetPullRequest(ctx, pr.PullRequestID); err != nil || got.Status != entities.PullRequestStatusClosed {
+		t.Fatalf("expected PR to stay CLOSED, got %+v, %v", got, err)
+	}
+
+	if err := repo.OpenPullRequest(ctx, pr.PullRequestID, entities.PullRequestStatusClosed, nil, nil); err != nil {
+		t.Fatalf("OpenPullRequest(reopen): %v", err)
+	}
+
+	reopened, _, err := repo.GetPullRequest(ctx, pr.PullRequestID)
+	if err != nil {
+		t.Fatalf("GetPullRequest: %v", err)
+	}
+	if reopened.Status != entities.PullRequestStatusOpen || reopened.ClosedAt != nil {
+		t.Fatalf("expected OPEN PR without closed_at, got %+v", reopened)
+	}
+}
+
+func TestTeamCodeownersIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	teamName := fmt.Sprintf("int_team_codeowners_%d", time.Now().UnixNano())
+	if err := repo.CreateTeam(ctx, entities.Team{TeamName: teamName}); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+
+	if _, err := repo.GetTeamCodeowners(ctx, teamName); !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("expected ErrNoRows before upload, got %v", err)
+	}
+
+	if _, err := repo.UpsertTeamCodeowners(ctx, teamName, "* @a"); err != nil {
+		t.Fatalf("UpsertTeamCodeowners(first): %v", err)
+	}
+	if _, err := repo.UpsertTeamCodeowners(ctx, teamName, "*.go @b"); err != nil {
+		t.Fatalf("UpsertTeamCodeowners(second): %v", err)
+	}
+
+	got, err := repo.GetTeamCodeowners(ctx, teamName)
+	if err != nil {
+		t.Fatalf("GetTeamCodeowners: %v", err)
+	}
+	if got.Content != "*.go @b" {
+		t.Fatalf("expected latest content, got %q", got.Content)
+	}
+}
+
+func TestWebhookSecretsIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	tenantID := fmt.Sprintf("int_tenant_hooks_%d", time.Now().UnixNano())
+	if _, err := repo.CreateTenant(ctx, entities.Tenant{TenantID: tenantID, Name: "Hooks"}); err != nil {
+		t.Fatalf("CreateTenant: %v", err)
+	}
+
+	for _, secret := range []string{tenantID + "_old", tenantID + "_new"} {
+		if err := repo.SetWebhookSecret(ctx, entities.TenantWebhookSecret{
+			TenantID: tenantID,
+			Provider: entities.IdentityProviderGitHub,
+			Secret:   secret,
+		}); err != nil {
+			t.Fatalf("SetWebhookSecret(%s): %v", secret, err)
+		}
+	}
+
+	secrets, err := repo.ListWebhookSecrets(ctx, entities.IdentityProviderGitHub)
+	if err != nil {
+		t.Fatalf("ListWebhookSecrets: %v", err)
+	}
+	found := 0
+	for _, s := range secrets {
+		if s.TenantID == tenantID {
+			found++
+			if s.Secret != tenantID+"_new" {
+				t.Fatalf("expected rotated secret, got %q", s.Secret)
+			}
+		}
+	}
+	if found != 1 {
+		t.Fatalf("expected one github secret for %s, got %d", tenantID, found)
+	}
+
+	gitlab, err := repo.ListWebhookSecrets(ctx, entities.IdentityProviderGitLab)
+	if err != nil {
+		t.Fatalf("ListWebhookSecrets(gitlab): %v", err)
+	}
+	for _, s := range gitlab {
+		if s.TenantID == tenantID {
+			t.Fatalf("github secret listed for gitlab")
+		}
+	}
+}
+
+func TestTenantIsolationIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+
+	ts := time.Now().UnixNano()
+	tenantID := fmt.Sprintf("int_tenant_%d", ts)
+	if _, err := repo.CreateTenant(context.Background(), entities.Tenant{TenantID: tenantID, Name: "Integration"}); err != nil {
+		t.Fatalf("CreateTenant: %v", err)
+	}
+	if exists, err := repo.TenantExists(context.Background(), tenantID); err != nil || !exists {
+		t.Fatalf("TenantExists = %v, %v", exists, err)
+	}
+
+	// The same team, user and PR ids live independently in both tenants.
+	teamName := fmt.Sprintf("int_team_tenant_%d", ts)
+	authorID := teamName + "_author"
+	reviewerID := teamName + "_reviewer"
+	prID := fmt.Sprintf("int_pr_tenant_%d", ts)
+
+	for i, ctx := range []context.Context{
+		context.Background(),
+		entities.WithTenant(context.Background(), tenantID),
+	} {
+		if err := repo.CreateTeam(ctx, entities.Team{
+			TeamName: teamName,
+			Members: []entities.TeamMember{
+				{UserID: authorID, Username: fmt.Sprintf("Author %d", i), IsActive: true},
+				{UserID: reviewerID, Username: "Reviewer", IsActive: true},
+			},
+		}); err != nil {
+			t.Fatalf("CreateTeam(%d): %v", i, err)
+		}
+		if err := repo.CreatePullRequest(ctx, entities.PullRequest{
+			PullRequestID:   prID,
+			PullRequestName: fmt.Sprintf("PR %d", i),
+			AuthorID:        authorID,
+			Status:          "OPEN",
+		}, []string{reviewerID}, nil); err != nil {
+			t.Fatalf("CreatePullRequest(%d): %v", i, err)
+		}
+	}
+
+	tenantCtx := entities.WithTenant(context.Background(), tenantID)
+	user, err := repo.GetUserByID(tenantCtx, authorID)
+	if err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+	if user.Username != "Author 1" {
+		t.Fatalf("expected tenant's own user, got %+v", user)
+	}
+
+	if _, _, err := repo.MarkPullRequestMerged(tenantCtx, prID, nil); err != nil {
+		t.Fatalf("MarkPullRequestMerged: %v", err)
+	}
+	pr, _, err := repo.GetPullRequest(context.Background(), prID)
+	if err != nil {
+		t.Fatalf("GetPullRequest(default): %v", err)
+	}
+	if pr.Status != "OPEN" {
+		t.Fatalf("merge in one tenant leaked into default: %+v", pr)
+	}
+
+	other := entities.WithTenant(context.Background(), tenantID+"_other")
+	if exists, err := repo.TeamExists(other, teamName); err != nil || exists {
+		t.Fatalf("TeamExists(other tenant) = %v, %v", exists, err)
+	}
+}
+
+func TestLinkIdentityIntegration(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+
+	teamName := fmt.Sprintf("int_team_identity_%d", time.Now().UnixNano())
+	alice, bob := teamName+"_alice", teamName+"_bob"
+	if err := repo.CreateTeam(ctx, entities.Team{
+		TeamName: teamName,
+		Members: []entities.TeamMember{
+			{UserID: alice, Username: "Alice", IsActive: true},
+			{UserID: bob, Username: "Bob", IsActive: true},
+		},
+	}); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+
+	identity := entities.UserIdentity{Provider: "github", Login: teamName + "-gh", UserID: alice}
+	for i := 0; i < 2; i++ {
+		if err := repo.LinkIdentity(ctx, identity); err != nil {
+			t.Fatalf("LinkIdentity(%d): %v", i, err)
+		}
+	}
+
+	identity.UserID = bob
+	if err := repo.LinkIdentity(ctx, identity); !errors.Is(err, ErrIdentityLinked) {
+		t.Fatalf("expected ErrIdentityLinked, got %v", err)
+	}
+	if userID, err := repo.GetUserIDByIdentity(ctx, identity.Provider, identity.Login); err != nil || userID != alice {
+		t.Fatalf("GetUserIDByIdentity = %q, %v; want %q", userID, err, alice)
+	}
+}
+
 func usersToIDs(users []entities.User) []string {
 	res := make([]string, 0, len(users))
 	for _, u := range users {
@@ -2,12 +2,18 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 	"pr-service/internal/domain/entities"
 
 	"github.com/jackc/pgx/v4"
 )
 
-func (r *Repository) CreatePullRequest(ctx context.Context, pr entities.PullRequest, reviewers []string) error {
+func (r *Repository) CreatePullRequest(
+	ctx context.Context,
+	pr entities.PullRequest,
+	reviewers []string,
+	rotation *entities.Rotation,
+) error {
 	tx, err := r.DB.Begin(ctx)
 	if err != nil {
 		return err
@@ -18,20 +24,19 @@ func (r *Repository) CreatePullRequest(ctx context.Context, pr entities.PullRequ
 		}
 	}()
 
+	if reviewers, err = lockRotation(ctx, tx, rotation, reviewers); err != nil {
+		return err
+	}
+
 	if _, err = tx.Exec(ctx, `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
-		VALUES ($1, $2, $3, $4)
-	`, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status); err != nil {
+		INSERT INTO pull_requests (tenant_id, pull_request_id, pull_request_name, author_id, status, changed_files)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6::TEXT[], '{}'))
+	`, tenant(ctx), pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, pr.ChangedFiles); err != nil {
 		return err
 	}
 
-	for _, rid := range reviewers {
-		if _, err = tx.Exec(ctx, `
-			INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id)
-			VALUES ($1, $2)
-		`, pr.PullRequestID, rid); err != nil {
-			return err
-		}
+	if err = assignReviewers(ctx, tx, pr.PullRequestID, reviewers, "created"); err != nil {
+		return err
 	}
 
 	if err = tx.Commit(ctx); err != nil {
@@ -43,87 +48,317 @@ func (r *Repository) CreatePullRequest(ctx context.Context, pr entities.PullRequ
 func (r *Repository) GetPullRequest(ctx context.Context, prID string) (entities.PullRequest, []string, error) {
 	var pr entities.PullRequest
 	err := r.DB.QueryRow(ctx, `
-		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, closed_at, changed_files
 		FROM pull_requests
-		WHERE pull_request_id=$1
-	`, prID).
-		Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt)
+		WHERE tenant_id=$1 AND pull_request_id=$2
+	`, tenant(ctx), prID).
+		Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt, &pr.ClosedAt, &pr.ChangedFiles)
 	if err != nil {
 		return entities.PullRequest{}, nil, err
 	}
 
-	rows, err := r.DB.Query(ctx, `
-		SELECT reviewer_id
-		FROM pull_request_reviewers
-		WHERE pull_request_id=$1
-		ORDER BY reviewer_id
-	`, prID)
+	pr.Reviews, err = listReviews(ctx, r.DB, prID)
 	if err != nil {
 		return entities.PullRequest{}, nil, err
 	}
-	defer rows.Close()
+	pr.FallbackReviewers = fallbackReviewerIDs(pr.Reviews)
 
-	reviewers := make([]string, 0)
-	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
-			return entities.PullRequest{}, nil, err
+	return pr, reviewerIDs(pr.Reviews), nil
+}
+
+// MarkPullRequestMerged merges an OPEN PR; merging a MERGED one is a no-op.
+// Any other status, e.g. one set by a concurrent close, gives pgx.ErrNoRows.
+// Before merging an OPEN PR, check gets its reviews with the PR and reviewer
+// rows locked, so no review or reassignment can slip in between the check
+// and the merge; an error from check is returned as is.
+func (r *Repository) MarkPullRequestMerged(
+	ctx context.Context,
+	prID string,
+	check func(reviews []entities.ReviewerState) error,
+) (pr entities.PullRequest, reviewers []string, err error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return entities.PullRequest{}, nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
 		}
-		reviewers = append(reviewers, id)
+	}()
+
+	var prevStatus string
+	if err = tx.QueryRow(ctx, `
+		SELECT status
+		FROM pull_requests
+		WHERE tenant_id=$1 AND pull_request_id=$2
+		FOR UPDATE
+	`, tenant(ctx), prID).Scan(&prevStatus); err != nil {
+		return entities.PullRequest{}, nil, err
 	}
-	if err := rows.Err(); err != nil {
+	if prevStatus != entities.PullRequestStatusOpen && prevStatus != entities.PullRequestStatusMerged {
+		err = pgx.ErrNoRows
 		return entities.PullRequest{}, nil, err
 	}
 
-	return pr, reviewers, nil
-}
+	if prevStatus == entities.PullRequestStatusOpen && check != nil {
+		if _, err = tx.Exec(ctx, `
+			SELECT 1
+			FROM pull_request_reviewers
+			WHERE tenant_id=$1 AND pull_request_id=$2
+			FOR UPDATE
+		`, tenant(ctx), prID); err != nil {
+			return entities.PullRequest{}, nil, err
+		}
 
-func (r *Repository) MarkPullRequestMerged(ctx context.Context, prID string) (entities.PullRequest, []string, error) {
-	var pr entities.PullRequest
-	err := r.DB.QueryRow(ctx, `
+		var reviews []entities.ReviewerState
+		if reviews, err = listReviews(ctx, tx, prID); err != nil {
+			return entities.PullRequest{}, nil, err
+		}
+		if err = check(reviews); err != nil {
+			return entities.PullRequest{}, nil, err
+		}
+	}
+
+	if err = tx.QueryRow(ctx, `
 		UPDATE pull_requests
 		SET status = 'MERGED',
 		    merged_at = COALESCE(merged_at, NOW())
-		WHERE pull_request_id=$1
-		RETURNING pull_request_id, pull_request_name, author_id, status, created_at, merged_at
-	`, prID).
-		Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt)
-	if err != nil {
+		WHERE tenant_id=$1 AND pull_request_id=$2 AND status IN ('OPEN', 'MERGED')
+		RETURNING pull_request_id, pull_request_name, author_id, status, created_at, merged_at, closed_at, changed_files
+	`, tenant(ctx), prID).
+		Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt, &pr.ClosedAt, &pr.ChangedFiles); err != nil {
 		return entities.PullRequest{}, nil, err
 	}
 
-	rows, err := r.DB.Query(ctx, `
-		SELECT reviewer_id
-		FROM pull_request_reviewers
-		WHERE pull_request_id=$1
-		ORDER BY reviewer_id
-	`, prID)
+	if prevStatus != entities.PullRequestStatusMerged {
+		if err = insertAssignmentEvent(ctx, tx, entities.AssignmentEvent{
+			PullRequestID: prID,
+			EventType:     entities.AssignmentEventMerge,
+		}); err != nil {
+			return entities.PullRequest{}, nil, err
+		}
+	}
+
+	if pr.Reviews, err = listReviews(ctx, tx, prID); err != nil {
+		return entities.PullRequest{}, nil, err
+	}
+	pr.FallbackReviewers = fallbackReviewerIDs(pr.Reviews)
+
+	if err = tx.Commit(ctx); err != nil {
+		return entities.PullRequest{}, nil, err
+	}
+	return pr, reviewerIDs(pr.Reviews), nil
+}
+
+func (r *Repository) ClosePullRequest(ctx context.Context, prID string) (pr entities.PullRequest, reviewers []string, err error) {
+	tx, err := r.DB.Begin(ctx)
 	if err != nil {
 		return entities.PullRequest{}, nil, err
 	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if err = tx.QueryRow(ctx, `
+		UPDATE pull_requests
+		SET status = 'CLOSED',
+		    closed_at = NOW()
+		WHERE tenant_id=$1 AND pull_request_id=$2 AND status IN ('OPEN', 'DRAFT')
+		RETURNING pull_request_id, pull_request_name, author_id, status, created_at, merged_at, closed_at, changed_files
+	`, tenant(ctx), prID).
+		Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt, &pr.ClosedAt, &pr.ChangedFiles); err != nil {
+		return entities.PullRequest{}, nil, err
+	}
+
+	if err = insertAssignmentEvent(ctx, tx, entities.AssignmentEvent{
+		PullRequestID: prID,
+		EventType:     entities.AssignmentEventClose,
+	}); err != nil {
+		return entities.PullRequest{}, nil, err
+	}
+
+	if pr.Reviews, err = listReviews(ctx, tx, prID); err != nil {
+		return entities.PullRequest{}, nil, err
+	}
+	pr.FallbackReviewers = fallbackReviewerIDs(pr.Reviews)
+
+	if err = tx.Commit(ctx); err != nil {
+		return entities.PullRequest{}, nil, err
+	}
+	return pr, reviewerIDs(pr.Reviews), nil
+}
+
+func (r *Repository) OpenPullRequest(
+	ctx context.Context,
+	prID, fromStatus string,
+	reviewers []string,
+	rotation *entities.Rotation,
+) error {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if reviewers, err = lockRotation(ctx, tx, rotation, reviewers); err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE pull_requests
+		SET status = 'OPEN',
+		    closed_at = NULL
+		WHERE tenant_id=$1 AND pull_request_id=$2 AND status=$3
+	`, tenant(ctx), prID, fromStatus)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		err = pgx.ErrNoRows
+		return err
+	}
+
+	reason := "ready for review"
+	if fromStatus == entities.PullRequestStatusClosed {
+		reason = "reopened"
+		if err = insertAssignmentEvent(ctx, tx, entities.AssignmentEvent{
+			PullRequestID: prID,
+			EventType:     entities.AssignmentEventReopen,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err = assignReviewers(ctx, tx, prID, reviewers, reason); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return err
+	}
+	return nil
+}
+
+func listReviews(ctx context.Context, q querier, prID string) ([]entities.ReviewerState, error) {
+	rows, err := q.Query(ctx, `
+		SELECT rpr.reviewer_id, rpr.state, rpr.state_updated_at,
+		       CASE WHEN ru.team_name <> au.team_name THEN ru.team_name ELSE '' END
+		FROM pull_request_reviewers rpr
+		JOIN pull_requests p ON p.tenant_id = rpr.tenant_id AND p.pull_request_id = rpr.pull_request_id
+		JOIN users ru ON ru.tenant_id = rpr.tenant_id AND ru.user_id = rpr.reviewer_id
+		JOIN users au ON au.tenant_id = p.tenant_id AND au.user_id = p.author_id
+		WHERE rpr.tenant_id=$1 AND rpr.pull_request_id=$2
+		ORDER BY rpr.reviewer_id
+	`, tenant(ctx), prID)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
-	reviewers := make([]string, 0)
+	reviews := make([]entities.ReviewerState, 0)
 	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
-			return entities.PullRequest{}, nil, err
+		var rs entities.ReviewerState
+		if err := rows.Scan(&rs.ReviewerID, &rs.State, &rs.UpdatedAt, &rs.FallbackTeam); err != nil {
+			return nil, err
 		}
-		reviewers = append(reviewers, id)
+		reviews = append(reviews, rs)
 	}
 	if err := rows.Err(); err != nil {
-		return entities.PullRequest{}, nil, err
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func fallbackReviewerIDs(reviews []entities.ReviewerState) []string {
+	var ids []string
+	for _, rs := range reviews {
+		if rs.FallbackTeam != "" {
+			ids = append(ids, rs.ReviewerID)
+		}
+	}
+	return ids
+}
+
+func reviewerIDs(reviews []entities.ReviewerState) []string {
+	ids := make([]string, 0, len(reviews))
+	for _, rs := range reviews {
+		ids = append(ids, rs.ReviewerID)
+	}
+	return ids
+}
+
+// ReplaceReviewer returns the new reviewer, which rotation picks again from
+// the locked cursor when set.
+func (r *Repository) ReplaceReviewer(
+	ctx context.Context,
+	prID, oldUserID, newUserID, reason string,
+	rotation *entities.Rotation,
+) (string, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	picked, err := lockRotation(ctx, tx, rotation, []string{newUserID})
+	if err != nil {
+		return "", err
+	}
+	if len(picked) != 1 {
+		err = fmt.Errorf("rotation picked %d reviewers, expected 1", len(picked))
+		return "", err
+	}
+	newUserID = picked[0]
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE pull_request_reviewers
+		SET reviewer_id=$3, state='PENDING', state_updated_at=NULL,
+		    assigned_at=NOW(), reminded_at=NULL, escalated_at=NULL,
+		    first_response_at=NULL, approved_at=NULL
+		WHERE tenant_id=$4 AND pull_request_id=$1 AND reviewer_id=$2
+	`, prID, oldUserID, newUserID, tenant(ctx))
+	if err != nil {
+		return "", err
+	}
+	if tag.RowsAffected() == 0 {
+		err = pgx.ErrNoRows
+		return "", err
 	}
 
-	return pr, reviewers, nil
+	if err = insertAssignmentEvent(ctx, tx, entities.AssignmentEvent{
+		PullRequestID: prID,
+		EventType:     entities.AssignmentEventReassign,
+		OldReviewerID: oldUserID,
+		NewReviewerID: newUserID,
+		Reason:        reason,
+	}); err != nil {
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return "", err
+	}
+	return newUserID, nil
 }
 
-func (r *Repository) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID string) error {
+func (r *Repository) SetReviewState(ctx context.Context, prID, reviewerID, state string) error {
 	tag, err := r.DB.Exec(ctx, `
 		UPDATE pull_request_reviewers
-		SET reviewer_id=$3
-		WHERE pull_request_id=$1 AND reviewer_id=$2
-	`, prID, oldUserID, newUserID)
+		SET state=$3, state_updated_at=NOW(),
+		    first_response_at=COALESCE(first_response_at, NOW()),
+		    approved_at=CASE WHEN $3='APPROVED' THEN COALESCE(approved_at, NOW()) ELSE approved_at END
+		WHERE tenant_id=$4 AND pull_request_id=$1 AND reviewer_id=$2
+	`, prID, reviewerID, state, tenant(ctx))
 	if err != nil {
 		return err
 	}
@@ -133,14 +368,19 @@ func (r *Repository) ReplaceReviewer(ctx context.Context, prID, oldUserID, newUs
 	return nil
 }
 
-func (r *Repository) ListPullRequestsByReviewer(ctx context.Context, reviewerID string) ([]entities.PullRequestShort, error) {
+func (r *Repository) ListPullRequestsByReviewer(
+	ctx context.Context,
+	reviewerID string,
+	onlyPending bool,
+) ([]entities.PullRequestShort, error) {
 	rows, err := r.DB.Query(ctx, `
-		SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status
+		SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status, rpr.state
 		FROM pull_requests p
-		JOIN pull_request_reviewers rpr ON rpr.pull_request_id = p.pull_request_id
-		WHERE rpr.reviewer_id = $1
+		JOIN pull_request_reviewers rpr ON rpr.tenant_id = p.tenant_id AND rpr.pull_request_id = p.pull_request_id
+		WHERE p.tenant_id = $3 AND rpr.reviewer_id = $1
+		  AND (NOT $2 OR (p.status = 'OPEN' AND rpr.state = 'PENDING'))
 		ORDER BY p.created_at DESC
-	`, reviewerID)
+	`, reviewerID, onlyPending, tenant(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -149,7 +389,7 @@ func (r *Repository) ListPullRequestsByReviewer(ctx context.Context, reviewerID
 	res := make([]entities.PullRequestShort, 0)
 	for rows.Next() {
 		var pr entities.PullRequestShort
-		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status); err != nil {
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.ReviewState); err != nil {
 			return nil, err
 		}
 		res = append(res, pr)
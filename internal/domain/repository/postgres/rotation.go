@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"pr-service/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// GetRotationCursor returns the last reviewer assigned by round_robin, or an
+// empty string if the team has not used it yet.
+func (r *Repository) GetRotationCursor(ctx context.Context, teamName string) (string, error) {
+	var cursor string
+	err := r.DB.QueryRow(ctx, `
+		SELECT last_user_id
+		FROM team_rotation_cursors
+		WHERE tenant_id=$1 AND team_name=$2
+	`, tenant(ctx), teamName).Scan(&cursor)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return cursor, err
+}
+
+// lockRotation locks the cursors of the rotation's teams until the end of
+// the transaction and repeats its picks from them, so concurrent assignments
+// of a team pick one after another from the latest cursor. It returns the
+// reviewers to store, which are the given ones when rotation is nil.
+func lockRotation(ctx context.Context, tx pgx.Tx, rotation *entities.Rotation, reviewers []string) ([]string, error) {
+	if rotation == nil {
+		return reviewers, nil
+	}
+
+	cursors := make(map[string]string, len(rotation.Teams))
+	for _, team := range rotation.Teams {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO team_rotation_cursors (tenant_id, team_name)
+			VALUES ($1, $2)
+			ON CONFLICT (tenant_id, team_name) DO NOTHING
+		`, tenant(ctx), team); err != nil {
+			return nil, err
+		}
+
+		var cursor string
+		if err := tx.QueryRow(ctx, `
+			SELECT last_user_id
+			FROM team_rotation_cursors
+			WHERE tenant_id=$1 AND team_name=$2
+			FOR UPDATE
+		`, tenant(ctx), team).Scan(&cursor); err != nil {
+			return nil, err
+		}
+		cursors[team] = cursor
+	}
+
+	picked, next := rotation.Pick(cursors)
+	for _, team := range rotation.Teams {
+		cursor, ok := next[team]
+		if !ok || cursor == cursors[team] {
+			continue
+		}
+		if _, err := tx.Exec(ctx, `
+			UPDATE team_rotation_cursors
+			SET last_user_id=$3, updated_at=NOW()
+			WHERE tenant_id=$1 AND team_name=$2
+		`, tenant(ctx), team, cursor); err != nil {
+			return nil, err
+		}
+	}
+	return picked, nil
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"pr-service/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// ListStaleReviews returns PENDING reviews on OPEN pull requests that are past
+// the reminder or escalation threshold of the author's team. A failed
+// escalation is retried only after another escalation period. It scans all
+// tenants, so callers must scope follow-up calls by StaleReview.TenantID.
+func (r *Repository) ListStaleReviews(ctx context.Context, limit int) ([]entities.StaleReview, error) {
+	rows, err := r.DB.Query(ctx, `
+		SELECT tenant_id, pull_request_id, pull_request_name, author_id, reviewer_id, team_name,
+		       created_at, assigned_at, reminded_at, escalate, escalate_after_minutes
+		FROM (
+			SELECT p.tenant_id, p.pull_request_id, p.pull_request_name, p.author_id, rpr.reviewer_id, au.team_name,
+			       p.created_at, rpr.assigned_at, rpr.reminded_at, ts.escalate_after_minutes,
+			       (ts.reminder_after_minutes > 0
+			        AND rpr.reminded_at IS NULL
+			        AND rpr.assigned_at <= NOW() - make_interval(mins => ts.reminder_after_minutes)) AS remind,
+			       (ts.escalate_after_minutes > 0
+			        AND rpr.assigned_at <= NOW() - make_interval(mins => ts.escalate_after_minutes)
+			        AND (rpr.escalated_at IS NULL
+			             OR rpr.escalated_at <= NOW() - make_interval(mins => ts.escalate_after_minutes))) AS escalate
+			FROM pull_request_reviewers rpr
+			JOIN pull_requests p ON p.tenant_id = rpr.tenant_id AND p.pull_request_id = rpr.pull_request_id
+			JOIN users au ON au.tenant_id = p.tenant_id AND au.user_id = p.author_id
+			JOIN team_settings ts ON ts.tenant_id = au.tenant_id AND ts.team_name = au.team_name
+			WHERE p.status = 'OPEN' AND rpr.state = 'PENDING'
+		) s
+		WHERE remind OR escalate
+		ORDER BY created_at
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]entities.StaleReview, 0)
+	for rows.Next() {
+		var sr entities.StaleReview
+		if err := rows.Scan(
+			&sr.TenantID, &sr.PullRequestID, &sr.PullRequestName, &sr.AuthorID, &sr.ReviewerID, &sr.TeamName,
+			&sr.CreatedAt, &sr.AssignedAt, &sr.RemindedAt, &sr.Escalate, &sr.EscalateAfter,
+		); err != nil {
+			return nil, err
+		}
+		res = append(res, sr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Repository) MarkReviewReminded(ctx context.Context, prID, reviewerID string) error {
+	return r.touchReviewer(ctx, `reminded_at`, prID, reviewerID)
+}
+
+func (r *Repository) MarkEscalationAttempted(ctx context.Context, prID, reviewerID string) error {
+	return r.touchReviewer(ctx, `escalated_at`, prID, reviewerID)
+}
+
+func (r *Repository) touchReviewer(ctx context.Context, column, prID, reviewerID string) error {
+	tag, err := r.DB.Exec(ctx, `
+		UPDATE pull_request_reviewers
+		SET `+column+` = NOW()
+		WHERE tenant_id=$3 AND pull_request_id=$1 AND reviewer_id=$2
+	`, prID, reviewerID, tenant(ctx))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
@@ -3,15 +3,40 @@ package postgres
 import (
 	"context"
 	"pr-service/internal/domain/entities"
+	"time"
 )
 
-func (r *Repository) GetAssignmentsStats(ctx context.Context) ([]entities.ReviewerAssignmentsStat, error) {
+// GetAssignmentsStats counts non-closed review assignments per user. Active
+// users without assignments are listed with zero so idle reviewers show up.
+func (r *Repository) GetAssignmentsStats(
+	ctx context.Context,
+	f entities.AssignmentsStatsFilter,
+) ([]entities.ReviewerAssignmentsStat, error) {
+	var from, to *time.Time
+	if !f.From.IsZero() {
+		from = &f.From
+	}
+	if !f.To.IsZero() {
+		to = &f.To
+	}
+
 	rows, err := r.DB.Query(ctx, `
-		SELECT reviewer_id AS user_id, COUNT(*) AS assignments
-		FROM pull_request_reviewers
-		GROUP BY reviewer_id
-		ORDER BY reviewer_id
-	`)
+		SELECT u.user_id, u.team_name, u.is_active, COUNT(a.pull_request_id) AS assignments
+		FROM users u
+		LEFT JOIN (
+			SELECT rpr.reviewer_id, rpr.pull_request_id
+			FROM pull_request_reviewers rpr
+			JOIN pull_requests pr ON pr.tenant_id = rpr.tenant_id AND pr.pull_request_id = rpr.pull_request_id
+			WHERE rpr.tenant_id = $5 AND pr.status <> 'CLOSED'
+			  AND ($1 = '' OR pr.status::TEXT = $1)
+			  AND ($2::TIMESTAMPTZ IS NULL OR rpr.assigned_at >= $2)
+			  AND ($3::TIMESTAMPTZ IS NULL OR rpr.assigned_at < $3)
+		) a ON a.reviewer_id = u.user_id
+		WHERE u.tenant_id = $5 AND ($4 = '' OR u.team_name = $4)
+		GROUP BY u.user_id, u.team_name, u.is_active
+		HAVING u.is_active OR COUNT(a.pull_request_id) > 0
+		ORDER BY u.team_name, u.user_id
+	`, f.Status, from, to, f.TeamName, tenant(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -20,7 +45,7 @@ func (r *Repository) GetAssignmentsStats(ctx context.Context) ([]entities.Review
 	stats := make([]entities.ReviewerAssignmentsStat, 0)
 	for rows.Next() {
 		var s entities.ReviewerAssignmentsStat
-		if err := rows.Scan(&s.UserID, &s.Assignments); err != nil {
+		if err := rows.Scan(&s.UserID, &s.TeamName, &s.IsActive, &s.Assignments); err != nil {
 			return nil, err
 		}
 		stats = append(stats, s)
@@ -30,3 +55,85 @@ func (r *Repository) GetAssignmentsStats(ctx context.Context) ([]entities.Review
 	}
 	return stats, nil
 }
+
+// ListReviewTimings returns every reviewer assignment of non-draft PRs created
+// in [from, to), optionally limited to the author's team.
+func (r *Repository) ListReviewTimings(ctx context.Context, from, to time.Time, teamName string) ([]entities.ReviewTiming, error) {
+	rows, err := r.DB.Query(ctx, `
+		SELECT p.pull_request_id, au.team_name, COALESCE(rpr.reviewer_id, ''),
+		       p.created_at, p.merged_at, rpr.assigned_at, rpr.first_response_at, rpr.approved_at
+		FROM pull_requests p
+		JOIN users au ON au.tenant_id = p.tenant_id AND au.user_id = p.author_id
+		LEFT JOIN pull_request_reviewers rpr ON rpr.tenant_id = p.tenant_id AND rpr.pull_request_id = p.pull_request_id
+		WHERE p.tenant_id = $4 AND p.created_at >= $1 AND p.created_at < $2
+		  AND p.status <> 'DRAFT'
+		  AND ($3 = '' OR au.team_name = $3)
+		ORDER BY p.pull_request_id
+	`, from, to, teamName, tenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]entities.ReviewTiming, 0)
+	for rows.Next() {
+		var rt entities.ReviewTiming
+		if err := rows.Scan(
+			&rt.PullRequestID, &rt.TeamName, &rt.ReviewerID,
+			&rt.CreatedAt, &rt.MergedAt, &rt.AssignedAt, &rt.FirstResponseAt, &rt.ApprovedAt,
+		); err != nil {
+			return nil, err
+		}
+		res = append(res, rt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListSLABreaches returns PENDING reviews on OPEN PRs waiting longer than the
+// team's SLA: the reminder threshold, or the escalation one if no reminder is
+// configured.
+func (r *Repository) ListSLABreaches(ctx context.Context, teamName string) ([]entities.SLABreach, error) {
+	rows, err := r.DB.Query(ctx, `
+		SELECT pull_request_id, pull_request_name, author_id, team_name, reviewer_id, assigned_at,
+		       (EXTRACT(EPOCH FROM NOW() - assigned_at) / 60)::INT,
+		       sla_minutes,
+		       escalate_after_minutes > 0 AND assigned_at <= NOW() - make_interval(mins => escalate_after_minutes)
+		FROM (
+			SELECT p.pull_request_id, p.pull_request_name, p.author_id, au.team_name, rpr.reviewer_id,
+			       rpr.assigned_at, ts.escalate_after_minutes,
+			       COALESCE(NULLIF(ts.reminder_after_minutes, 0), NULLIF(ts.escalate_after_minutes, 0)) AS sla_minutes
+			FROM pull_request_reviewers rpr
+			JOIN pull_requests p ON p.tenant_id = rpr.tenant_id AND p.pull_request_id = rpr.pull_request_id
+			JOIN users au ON au.tenant_id = p.tenant_id AND au.user_id = p.author_id
+			JOIN team_settings ts ON ts.tenant_id = au.tenant_id AND ts.team_name = au.team_name
+			WHERE rpr.tenant_id = $2 AND p.status = 'OPEN' AND rpr.state = 'PENDING'
+			  AND ($1 = '' OR au.team_name = $1)
+		) s
+		WHERE sla_minutes IS NOT NULL
+		  AND assigned_at <= NOW() - make_interval(mins => sla_minutes)
+		ORDER BY assigned_at, pull_request_id, reviewer_id
+	`, teamName, tenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]entities.SLABreach, 0)
+	for rows.Next() {
+		var b entities.SLABreach
+		if err := rows.Scan(
+			&b.PullRequestID, &b.PullRequestName, &b.AuthorID, &b.TeamName, &b.ReviewerID, &b.AssignedAt,
+			&b.WaitingMinutes, &b.SLAMinutes, &b.Escalated,
+		); err != nil {
+			return nil, err
+		}
+		res = append(res, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -7,7 +7,7 @@ import (
 
 func (r *Repository) TeamExists(ctx context.Context, teamName string) (bool, error) {
 	var exists bool
-	err := r.DB.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM teams WHERE team_name=$1)`, teamName).Scan(&exists)
+	err := r.DB.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM teams WHERE tenant_id=$1 AND team_name=$2)`, tenant(ctx), teamName).Scan(&exists)
 	if err != nil {
 		return false, err
 	}
@@ -26,21 +26,24 @@ func (r *Repository) CreateTeam(ctx context.Context, team entities.Team) error {
 	}()
 
 	if _, err = tx.Exec(ctx,
-		`INSERT INTO teams (team_name) VALUES ($1)`,
-		team.TeamName,
+		`INSERT INTO teams (tenant_id, team_name) VALUES ($1, $2)`,
+		tenant(ctx), team.TeamName,
 	); err != nil {
 		return err
 	}
 
 	for _, m := range team.Members {
 		if _, err = tx.Exec(ctx, `
-			INSERT INTO users (user_id, username, team_name, is_active)
-			VALUES ($1, $2, $3, $4)
-			ON CONFLICT (user_id) DO UPDATE
+			INSERT INTO users (tenant_id, user_id, username, team_name, is_active, timezone, work_start, work_end)
+			VALUES ($8, $1, $2, $3, $4, COALESCE(NULLIF($5, ''), 'UTC'), COALESCE(NULLIF($6, ''), '09:00'), COALESCE(NULLIF($7, ''), '18:00'))
+			ON CONFLICT (tenant_id, user_id) DO UPDATE
 			SET username = EXCLUDED.username,
 			    team_name = EXCLUDED.team_name,
-			    is_active = EXCLUDED.is_active
-		`, m.UserID, m.Username, team.TeamName, m.IsActive); err != nil {
+			    is_active = EXCLUDED.is_active,
+			    timezone = EXCLUDED.timezone,
+			    work_start = EXCLUDED.work_start,
+			    work_end = EXCLUDED.work_end
+		`, m.UserID, m.Username, team.TeamName, m.IsActive, m.Timezone, m.WorkStart, m.WorkEnd, tenant(ctx)); err != nil {
 			return err
 		}
 	}
@@ -54,18 +57,18 @@ func (r *Repository) CreateTeam(ctx context.Context, team entities.Team) error {
 func (r *Repository) GetTeam(ctx context.Context, teamName string) (entities.Team, error) {
 	var name string
 	if err := r.DB.QueryRow(ctx,
-		`SELECT team_name FROM teams WHERE team_name=$1`,
-		teamName,
+		`SELECT team_name FROM teams WHERE tenant_id=$1 AND team_name=$2`,
+		tenant(ctx), teamName,
 	).Scan(&name); err != nil {
 		return entities.Team{}, err
 	}
 
 	rows, err := r.DB.Query(ctx, `
-		SELECT user_id, username, is_active
+		SELECT user_id, username, is_active, timezone, work_start, work_end
 		FROM users
-		WHERE team_name=$1
+		WHERE tenant_id=$1 AND team_name=$2
 		ORDER BY user_id
-	`, teamName)
+	`, tenant(ctx), teamName)
 	if err != nil {
 		return entities.Team{}, err
 	}
@@ -74,7 +77,7 @@ func (r *Repository) GetTeam(ctx context.Context, teamName string) (entities.Tea
 	members := make([]entities.TeamMember, 0)
 	for rows.Next() {
 		var m entities.TeamMember
-		if err := rows.Scan(&m.UserID, &m.Username, &m.IsActive); err != nil {
+		if err := rows.Scan(&m.UserID, &m.Username, &m.IsActive, &m.Timezone, &m.WorkStart, &m.WorkEnd); err != nil {
 			return entities.Team{}, err
 		}
 		members = append(members, m)
@@ -88,3 +91,58 @@ func (r *Repository) GetTeam(ctx context.Context, teamName string) (entities.Tea
 		Members:  members,
 	}, nil
 }
+
+func (r *Repository) GetTeamSettings(ctx context.Context, teamName string) (entities.TeamSettings, error) {
+	var s entities.TeamSettings
+	err := r.DB.QueryRow(ctx, `
+		SELECT t.team_name,
+		       COALESCE(s.assignment_strategy, $2),
+		       COALESCE(s.reviewers_count, $3),
+		       COALESCE(s.min_approvals, 0),
+		       COALESCE(s.require_all_approved, FALSE),
+		       COALESCE(s.fallback_teams, '{}'),
+		       COALESCE(s.max_open_reviews, 0),
+		       COALESCE(s.prefer_working_hours, FALSE),
+		       COALESCE(s.reminder_after_minutes, 0),
+		       COALESCE(s.escalate_after_minutes, 0)
+		FROM teams t
+		LEFT JOIN team_settings s ON s.tenant_id = t.tenant_id AND s.team_name = t.team_name
+		WHERE t.tenant_id=$4 AND t.team_name=$1
+	`, teamName, entities.DefaultAssignmentStrategy, entities.DefaultReviewersCount, tenant(ctx)).
+		Scan(
+			&s.TeamName, &s.AssignmentStrategy, &s.ReviewersCount, &s.MinApprovals, &s.RequireAllApproved,
+			&s.FallbackTeams, &s.MaxOpenReviews, &s.PreferWorkingHours,
+			&s.ReminderAfterMinutes, &s.EscalateAfterMinutes,
+		)
+	if err != nil {
+		return entities.TeamSettings{}, err
+	}
+	return s, nil
+}
+
+func (r *Repository) UpsertTeamSettings(ctx context.Context, s entities.TeamSettings) error {
+	_, err := r.DB.Exec(ctx, `
+		INSERT INTO team_settings (
+			tenant_id, team_name, assignment_strategy, reviewers_count, min_approvals, require_all_approved,
+			fallback_teams, max_open_reviews, prefer_working_hours,
+			reminder_after_minutes, escalate_after_minutes
+		)
+		VALUES ($11, $1, $2, $3, $4, $5, COALESCE($6::TEXT[], '{}'), $7, $8, $9, $10)
+		ON CONFLICT (tenant_id, team_name) DO UPDATE
+		SET assignment_strategy = EXCLUDED.assignment_strategy,
+		    reviewers_count = EXCLUDED.reviewers_count,
+		    min_approvals = EXCLUDED.min_approvals,
+		    require_all_approved = EXCLUDED.require_all_approved,
+		    fallback_teams = EXCLUDED.fallback_teams,
+		    max_open_reviews = EXCLUDED.max_open_reviews,
+		    prefer_working_hours = EXCLUDED.prefer_working_hours,
+		    reminder_after_minutes = EXCLUDED.reminder_after_minutes,
+		    escalate_after_minutes = EXCLUDED.escalate_after_minutes,
+		    updated_at = NOW()
+	`,
+		s.TeamName, s.AssignmentStrategy, s.ReviewersCount, s.MinApprovals, s.RequireAllApproved,
+		s.FallbackTeams, s.MaxOpenReviews, s.PreferWorkingHours,
+		s.ReminderAfterMinutes, s.EscalateAfterMinutes, tenant(ctx),
+	)
+	return err
+}
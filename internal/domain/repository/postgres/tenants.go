@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"pr-service/internal/domain/entities"
+)
+
+func (r *Repository) CreateTenant(ctx context.Context, t entities.Tenant) (entities.Tenant, error) {
+	err := r.DB.QueryRow(ctx, `
+		INSERT INTO tenants (tenant_id, name)
+		VALUES ($1, $2)
+		RETURNING tenant_id, name, created_at
+	`, t.TenantID, t.Name).Scan(&t.TenantID, &t.Name, &t.CreatedAt)
+	return t, err
+}
+
+func (r *Repository) ListTenants(ctx context.Context) ([]entities.Tenant, error) {
+	rows, err := r.DB.Query(ctx, `
+		SELECT tenant_id, name, created_at
+		FROM tenants
+		ORDER BY tenant_id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tenants := make([]entities.Tenant, 0)
+	for rows.Next() {
+		var t entities.Tenant
+		if err := rows.Scan(&t.TenantID, &t.Name, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
+
+func (r *Repository) TenantExists(ctx context.Context, tenantID string) (bool, error) {
+	var exists bool
+	err := r.DB.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM tenants WHERE tenant_id=$1)`, tenantID).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// SetWebhookSecret replaces the tenant's secret for the provider.
+func (r *Repository) SetWebhookSecret(ctx context.Context, s entities.TenantWebhookSecret) error {
+	_, err := r.DB.Exec(ctx, `
+		INSERT INTO tenant_webhook_secrets (tenant_id, provider, secret)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id, provider)
+		DO UPDATE SET secret = EXCLUDED.secret, updated_at = NOW()
+	`, s.TenantID, s.Provider, s.Secret)
+	return err
+}
+
+// ListWebhookSecrets returns the provider's secrets of all tenants.
+func (r *Repository) ListWebhookSecrets(ctx context.Context, provider string) ([]entities.TenantWebhookSecret, error) {
+	rows, err := r.DB.Query(ctx, `
+		SELECT tenant_id, provider, secret
+		FROM tenant_webhook_secrets
+		WHERE provider=$1
+		ORDER BY tenant_id
+	`, provider)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	secrets := make([]entities.TenantWebhookSecret, 0)
+	for rows.Next() {
+		var s entities.TenantWebhookSecret
+		if err := rows.Scan(&s.TenantID, &s.Provider, &s.Secret); err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("pr-service/repository")
+
+// tracedPool records a span per statement. Spans are named after the
+// repository function that issued the query, so a slow trace points at
+// ListTeamActiveUsersExcept rather than at an anonymous SELECT.
+type tracedPool struct {
+	PgxPool
+}
+
+func newTracedPool(pool PgxPool) PgxPool {
+	return &tracedPool{PgxPool: pool}
+}
+
+func (p *tracedPool) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	ctx, span := startQuerySpan(ctx, sql)
+	tag, err := p.PgxPool.Exec(ctx, sql, args...)
+	endExecSpan(span, tag, err)
+	return tag, err
+}
+
+func (p *tracedPool) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	ctx, span := startQuerySpan(ctx, sql)
+	rows, err := p.PgxPool.Query(ctx, sql, args...)
+	if err != nil {
+		endSpan(span, err)
+		return nil, err
+	}
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+func (p *tracedPool) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	ctx, span := startQuerySpan(ctx, sql)
+	return &tracedRow{row: p.PgxPool.QueryRow(ctx, sql, args...), span: span}
+}
+
+func (p *tracedPool) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := p.PgxPool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedTx{Tx: tx}, nil
+}
+
+type tracedTx struct {
+	pgx.Tx
+}
+
+func (t *tracedTx) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	ctx, span := startQuerySpan(ctx, sql)
+	tag, err := t.Tx.Exec(ctx, sql, args...)
+	endExecSpan(span, tag, err)
+	return tag, err
+}
+
+func (t *tracedTx) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	ctx, span := startQuerySpan(ctx, sql)
+	rows, err := t.Tx.Query(ctx, sql, args...)
+	if err != nil {
+		endSpan(span, err)
+		return nil, err
+	}
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+func (t *tracedTx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	ctx, span := startQuerySpan(ctx, sql)
+	return &tracedRow{row: t.Tx.QueryRow(ctx, sql, args...), span: span}
+}
+
+func (t *tracedTx) Commit(ctx context.Context) error {
+	ctx, span := startQuerySpan(ctx, "COMMIT")
+	err := t.Tx.Commit(ctx)
+	endSpan(span, err)
+	return err
+}
+
+func (t *tracedTx) Rollback(ctx context.Context) error {
+	ctx, span := startQuerySpan(ctx, "ROLLBACK")
+	err := t.Tx.Rollback(ctx)
+	if errors.Is(err, pgx.ErrTxClosed) {
+		err = nil
+	}
+	endSpan(span, err)
+	return err
+}
+
+// tracedRows ends its span on Close, once the row count is known. Callers
+// may close twice (explicitly and deferred), so only the first Close counts.
+type tracedRows struct {
+	pgx.Rows
+	span   trace.Span
+	count  int
+	closed bool
+}
+
+func (r *tracedRows) Next() bool {
+	if r.Rows.Next() {
+		r.count++
+		return true
+	}
+	return false
+}
+
+func (r *tracedRows) Close() {
+	r.Rows.Close()
+	if r.closed {
+		return
+	}
+	r.closed = true
+	r.span.SetAttributes(semconv.DBResponseReturnedRows(r.count))
+	endSpan(r.span, r.Rows.Err())
+}
+
+type tracedRow struct {
+	row  pgx.Row
+	span trace.Span
+}
+
+func (r *tracedRow) Scan(dest ...interface{}) error {
+	err := r.row.Scan(dest...)
+	switch {
+	case err == nil:
+		r.span.SetAttributes(semconv.DBResponseReturnedRows(1))
+		endSpan(r.span, nil)
+	case errors.Is(err, pgx.ErrNoRows):
+		r.span.SetAttributes(semconv.DBResponseReturnedRows(0))
+		endSpan(r.span, nil)
+	default:
+		endSpan(r.span, err)
+	}
+	return err
+}
+
+func startQuerySpan(ctx context.Context, sql string) (context.Context, trace.Span) {
+	query := strings.Join(strings.Fields(sql), " ")
+	operation, _, _ := strings.Cut(query, " ")
+
+	return tracer.Start(ctx, callerName(), trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemNamePostgreSQL,
+		semconv.DBOperationName(strings.ToUpper(operation)),
+		semconv.DBQueryText(query),
+	))
+}
+
+func endExecSpan(span trace.Span, tag pgconn.CommandTag, err error) {
+	if err == nil {
+		span.SetAttributes(attribute.Int64("db.rows_affected", tag.RowsAffected()))
+	}
+	endSpan(span, err)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// callerName returns the repository function that issued the query, e.g.
+// "Repository.CreatePullRequest" or "reassignOpenReviews".
+func callerName() string {
+	// startQuerySpan <- traced method <- repository code
+	pc, _, _, ok := runtime.Caller(3)
+	if !ok {
+		return "postgres.query"
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "postgres.query"
+	}
+	name := fn.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimPrefix(name, "postgres.")
+	name = strings.Replace(name, "(*Repository)", "Repository", 1)
+	// Closures are reported as Func.func1; the enclosing function is enough.
+	if i := strings.Index(name, ".func"); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
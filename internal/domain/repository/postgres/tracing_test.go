@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgproto3/v2"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var spanRecorder = tracetest.NewSpanRecorder()
+
+func init() {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+}
+
+type fakeRows struct {
+	left int
+}
+
+func (r *fakeRows) Close()                                         {}
+func (r *fakeRows) Err() error                                     { return nil }
+func (r *fakeRows) CommandTag() pgconn.CommandTag                  { return nil }
+func (r *fakeRows) FieldDescriptions() []pgproto3.FieldDescription { return nil }
+func (r *fakeRows) Scan(...interface{}) error                      { return nil }
+func (r *fakeRows) Values() ([]interface{}, error)                 { return nil, nil }
+func (r *fakeRows) RawValues() [][]byte                            { return nil }
+func (r *fakeRows) Next() bool {
+	if r.left == 0 {
+		return false
+	}
+	r.left--
+	return true
+}
+
+type fakeRow struct{ err error }
+
+func (r fakeRow) Scan(...interface{}) error { return r.err }
+
+type fakePool struct {
+	rows int
+}
+
+func (p *fakePool) Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag("UPDATE 3"), nil
+}
+
+func (p *fakePool) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	return &fakeRows{left: p.rows}, nil
+}
+
+func (p *fakePool) QueryRow(_ context.Context, sql string, _ ...interface{}) pgx.Row {
+	if sql == "broken" {
+		return fakeRow{err: errors.New("boom")}
+	}
+	return fakeRow{err: pgx.ErrNoRows}
+}
+
+func (p *fakePool) Begin(context.Context) (pgx.Tx, error) { return nil, errors.New("not supported") }
+func (p *fakePool) Ping(context.Context) error            { return nil }
+func (p *fakePool) Close()                                {}
+
+func lastSpan(t *testing.T) sdktrace.ReadOnlySpan {
+	t.Helper()
+	spans := spanRecorder.Ended()
+	if len(spans) == 0 {
+		t.Fatalf("no spans recorded")
+	}
+	return spans[len(spans)-1]
+}
+
+func spanAttr(span sdktrace.ReadOnlySpan, key string) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func listThings(db PgxPool) error {
+	rows, err := db.Query(context.Background(), `
+		SELECT id
+		FROM things
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+	}
+	rows.Close()
+	return rows.Err()
+}
+
+func TestTracedPoolQuery(t *testing.T) {
+	db := newTracedPool(&fakePool{rows: 4})
+	before := len(spanRecorder.Ended())
+
+	if err := listThings(db); err != nil {
+		t.Fatalf("listThings: %v", err)
+	}
+
+	if got := len(spanRecorder.Ended()) - before; got != 1 {
+		t.Fatalf("expected exactly one span despite double Close, got %d", got)
+	}
+	span := lastSpan(t)
+	if span.Name() != "listThings" {
+		t.Fatalf("span name = %q, want listThings", span.Name())
+	}
+	if v, _ := spanAttr(span, "db.query.text"); v.AsString() != "SELECT id FROM things" {
+		t.Fatalf("db.query.text = %q", v.AsString())
+	}
+	if v, _ := spanAttr(span, "db.operation.name"); v.AsString() != "SELECT" {
+		t.Fatalf("db.operation.name = %q", v.AsString())
+	}
+	if v, _ := spanAttr(span, "db.response.returned_rows"); v.AsInt64() != 4 {
+		t.Fatalf("returned rows = %d, want 4", v.AsInt64())
+	}
+}
+
+func TestTracedPoolExecAndQueryRow(t *testing.T) {
+	db := newTracedPool(&fakePool{})
+	ctx := context.Background()
+
+	if _, err := db.Exec(ctx, "UPDATE things SET x = 1"); err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+	if v, _ := spanAttr(lastSpan(t), "db.rows_affected"); v.AsInt64() != 3 {
+		t.Fatalf("rows affected = %d, want 3", v.AsInt64())
+	}
+
+	var id int
+	if err := db.QueryRow(ctx, "SELECT 1").Scan(&id); !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("expected ErrNoRows, got %v", err)
+	}
+	if span := lastSpan(t); span.Status().Code == codes.Error {
+		t.Fatalf("no rows must not mark the span as failed")
+	}
+
+	if err := db.QueryRow(ctx, "broken").Scan(&id); err == nil {
+		t.Fatalf("expected error")
+	}
+	if span := lastSpan(t); span.Status().Code != codes.Error {
+		t.Fatalf("expected error status, got %v", span.Status())
+	}
+}
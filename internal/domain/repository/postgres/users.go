@@ -3,17 +3,19 @@ package postgres
 import (
 	"context"
 	"pr-service/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4"
 )
 
 func (r *Repository) SetUserIsActive(ctx context.Context, userID string, isActive bool) (entities.User, error) {
 	var u entities.User
 	err := r.DB.QueryRow(ctx, `
 		UPDATE users
-		SET is_active=$2
-		WHERE user_id=$1
-		RETURNING user_id, username, team_name, is_active
-	`, userID, isActive).
-		Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive)
+		SET is_active=$3
+		WHERE tenant_id=$1 AND user_id=$2
+		RETURNING user_id, username, team_name, is_active, timezone, work_start, work_end
+	`, tenant(ctx), userID, isActive).
+		Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive, &u.Timezone, &u.WorkStart, &u.WorkEnd)
 	if err != nil {
 		return entities.User{}, err
 	}
@@ -23,11 +25,11 @@ func (r *Repository) SetUserIsActive(ctx context.Context, userID string, isActiv
 func (r *Repository) GetUserByID(ctx context.Context, userID string) (entities.User, error) {
 	var u entities.User
 	err := r.DB.QueryRow(ctx, `
-		SELECT user_id, username, team_name, is_active
+		SELECT user_id, username, team_name, is_active, timezone, work_start, work_end
 		FROM users
-		WHERE user_id=$1
-	`, userID).
-		Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive)
+		WHERE tenant_id=$1 AND user_id=$2
+	`, tenant(ctx), userID).
+		Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive, &u.Timezone, &u.WorkStart, &u.WorkEnd)
 	if err != nil {
 		return entities.User{}, err
 	}
@@ -36,10 +38,26 @@ func (r *Repository) GetUserByID(ctx context.Context, userID string) (entities.U
 
 func (r *Repository) ListTeamActiveUsersExcept(ctx context.Context, teamName, exceptUserID string) ([]entities.User, error) {
 	rows, err := r.DB.Query(ctx, `
-		SELECT user_id, username, team_name, is_active
-		FROM users
-		WHERE team_name=$1 AND is_active=TRUE AND user_id <> $2
-	`, teamName, exceptUserID)
+		SELECT u.user_id, u.username, u.team_name, u.is_active, u.timezone, u.work_start, u.work_end
+		FROM users u
+		LEFT JOIN team_settings ts ON ts.tenant_id = u.tenant_id AND ts.team_name = u.team_name
+		WHERE u.tenant_id=$3 AND u.team_name=$1 AND u.is_active=TRUE AND u.user_id <> $2
+		  AND NOT EXISTS (
+		      SELECT 1
+		      FROM user_unavailability ua
+		      WHERE ua.tenant_id = u.tenant_id AND ua.user_id = u.user_id
+		        AND NOW() >= ua.starts_at AND NOW() < ua.ends_at
+		  )
+		  AND (
+		      COALESCE(u.max_open_reviews, ts.max_open_reviews, 0) = 0
+		      OR COALESCE(u.max_open_reviews, ts.max_open_reviews) > (
+		          SELECT COUNT(*)
+		          FROM pull_request_reviewers rpr
+		          JOIN pull_requests p ON p.tenant_id = rpr.tenant_id AND p.pull_request_id = rpr.pull_request_id
+		          WHERE rpr.tenant_id = u.tenant_id AND rpr.reviewer_id = u.user_id AND p.status = 'OPEN'
+		      )
+		  )
+	`, teamName, exceptUserID, tenant(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +66,7 @@ func (r *Repository) ListTeamActiveUsersExcept(ctx context.Context, teamName, ex
 	users := make([]entities.User, 0)
 	for rows.Next() {
 		var u entities.User
-		if err := rows.Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+		if err := rows.Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive, &u.Timezone, &u.WorkStart, &u.WorkEnd); err != nil {
 			return nil, err
 		}
 		users = append(users, u)
@@ -58,3 +76,103 @@ func (r *Repository) ListTeamActiveUsersExcept(ctx context.Context, teamName, ex
 	}
 	return users, nil
 }
+
+func (r *Repository) GetOpenReviewLoad(ctx context.Context, userIDs []string) (map[string]int, error) {
+	return queryOpenReviewLoad(ctx, r.DB, userIDs)
+}
+
+func queryOpenReviewLoad(ctx context.Context, q querier, userIDs []string) (map[string]int, error) {
+	rows, err := q.Query(ctx, `
+		SELECT rpr.reviewer_id, COUNT(*)
+		FROM pull_request_reviewers rpr
+		JOIN pull_requests pr ON pr.tenant_id = rpr.tenant_id AND pr.pull_request_id = rpr.pull_request_id
+		WHERE rpr.tenant_id = $2 AND pr.status = 'OPEN'
+		  AND rpr.reviewer_id = ANY($1)
+		GROUP BY rpr.reviewer_id
+	`, userIDs, tenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	load := make(map[string]int, len(userIDs))
+	for rows.Next() {
+		var (
+			id    string
+			count int
+		)
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, err
+		}
+		load[id] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return load, nil
+}
+
+func (r *Repository) SetUserMaxOpenReviews(ctx context.Context, userID string, limit *int) error {
+	tag, err := r.DB.Exec(ctx, `
+		UPDATE users
+		SET max_open_reviews=$3
+		WHERE tenant_id=$1 AND user_id=$2
+	`, tenant(ctx), userID, limit)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *Repository) GetReviewCapacity(ctx context.Context, userID string) (entities.ReviewCapacity, error) {
+	var c entities.ReviewCapacity
+	err := r.DB.QueryRow(ctx, `
+		SELECT u.user_id,
+		       u.max_open_reviews,
+		       COALESCE(u.max_open_reviews, ts.max_open_reviews, 0),
+		       (
+		           SELECT COUNT(*)
+		           FROM pull_request_reviewers rpr
+		           JOIN pull_requests p ON p.tenant_id = rpr.tenant_id AND p.pull_request_id = rpr.pull_request_id
+		           WHERE rpr.tenant_id = u.tenant_id AND rpr.reviewer_id = u.user_id AND p.status = 'OPEN'
+		       )
+		FROM users u
+		LEFT JOIN team_settings ts ON ts.tenant_id = u.tenant_id AND ts.team_name = u.team_name
+		WHERE u.tenant_id=$1 AND u.user_id=$2
+	`, tenant(ctx), userID).Scan(&c.UserID, &c.Override, &c.MaxOpenReviews, &c.OpenReviews)
+	if err != nil {
+		return entities.ReviewCapacity{}, err
+	}
+	c.AtCapacity = c.MaxOpenReviews > 0 && c.OpenReviews >= c.MaxOpenReviews
+	return c, nil
+}
+
+func (r *Repository) GetUserRole(ctx context.Context, userID string) (entities.UserRole, error) {
+	var ur entities.UserRole
+	err := r.DB.QueryRow(ctx, `
+		SELECT user_id, team_name, role::TEXT
+		FROM users
+		WHERE tenant_id=$1 AND user_id=$2
+	`, tenant(ctx), userID).Scan(&ur.UserID, &ur.TeamName, &ur.Role)
+	if err != nil {
+		return entities.UserRole{}, err
+	}
+	return ur, nil
+}
+
+func (r *Repository) SetUserRole(ctx context.Context, userID, role string) (entities.UserRole, error) {
+	var ur entities.UserRole
+	err := r.DB.QueryRow(ctx, `
+		UPDATE users
+		SET role=$3::user_role
+		WHERE tenant_id=$1 AND user_id=$2
+		RETURNING user_id, team_name, role::TEXT
+	`, tenant(ctx), userID, role).Scan(&ur.UserID, &ur.TeamName, &ur.Role)
+	if err != nil {
+		return entities.UserRole{}, err
+	}
+	return ur, nil
+}
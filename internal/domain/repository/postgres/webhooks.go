@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"pr-service/internal/domain/entities"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+func (r *Repository) CreateWebhook(ctx context.Context, wh entities.Webhook) (entities.Webhook, error) {
+	if wh.EventTypes == nil {
+		wh.EventTypes = []string{}
+	}
+	err := r.DB.QueryRow(ctx, `
+		INSERT INTO webhooks (tenant_id, url, secret, event_types)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, is_active, created_at
+	`, tenant(ctx), wh.URL, wh.Secret, wh.EventTypes).
+		Scan(&wh.ID, &wh.IsActive, &wh.CreatedAt)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+	return wh, nil
+}
+
+func (r *Repository) ListWebhooks(ctx context.Context) ([]entities.Webhook, error) {
+	rows, err := r.DB.Query(ctx, `
+		SELECT id, url, event_types, is_active, created_at
+		FROM webhooks
+		WHERE tenant_id=$1
+		ORDER BY id
+	`, tenant(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]entities.Webhook, 0)
+	for rows.Next() {
+		var wh entities.Webhook
+		if err := rows.Scan(&wh.ID, &wh.URL, &wh.EventTypes, &wh.IsActive, &wh.CreatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, wh)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Repository) DeleteWebhook(ctx context.Context, id int64) error {
+	tag, err := r.DB.Exec(ctx, `DELETE FROM webhooks WHERE tenant_id=$1 AND id=$2`, tenant(ctx), id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *Repository) ClaimOutboxBatch(ctx context.Context, limit int, lease time.Duration) (res []entities.OutboxMessage, err error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	rows, err := tx.Query(ctx, `
+		SELECT o.id, o.webhook_id, w.url, w.secret, o.event_type, o.payload::text, o.attempts
+		FROM webhook_outbox o
+		JOIN webhooks w ON w.id = o.webhook_id
+		WHERE o.status = 'PENDING' AND o.next_attempt_at <= NOW()
+		ORDER BY o.id
+		LIMIT $1
+		FOR UPDATE OF o SKIP LOCKED
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0, limit)
+	for rows.Next() {
+		var (
+			m       entities.OutboxMessage
+			payload string
+		)
+		if err = rows.Scan(&m.ID, &m.WebhookID, &m.URL, &m.Secret, &m.EventType, &payload, &m.Attempts); err != nil {
+			return nil, err
+		}
+		m.Payload = []byte(payload)
+		res = append(res, m)
+		ids = append(ids, m.ID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if len(ids) > 0 {
+		if _, err = tx.Exec(ctx, `
+			UPDATE webhook_outbox
+			SET next_attempt_at = NOW() + make_interval(secs => $2)
+			WHERE id = ANY($1)
+		`, ids, lease.Seconds()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *Repository) MarkOutboxDelivered(ctx context.Context, id int64) error {
+	_, err := r.DB.Exec(ctx, `
+		UPDATE webhook_outbox
+		SET status = 'DELIVERED',
+		    attempts = attempts + 1,
+		    last_error = '',
+		    delivered_at = NOW()
+		WHERE id=$1
+	`, id)
+	return err
+}
+
+func (r *Repository) MarkOutboxFailed(ctx context.Context, id int64, nextAttemptAt time.Time, lastErr string, dead bool) error {
+	_, err := r.DB.Exec(ctx, `
+		UPDATE webhook_outbox
+		SET status = CASE WHEN $4 THEN 'DEAD'::outbox_status ELSE 'PENDING'::outbox_status END,
+		    attempts = attempts + 1,
+		    next_attempt_at = $2,
+		    last_error = $3
+		WHERE id=$1
+	`, id, nextAttemptAt, lastErr, dead)
+	return err
+}
@@ -0,0 +1,24 @@
+package tracing
+
+import (
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+func New() fx.Option {
+	return fx.Module("tracing",
+		fx.Provide(
+			NewProvider,
+		),
+		fx.Invoke(
+			func(lc fx.Lifecycle, p *Provider) {
+				lc.Append(fx.Hook{
+					OnStop: p.OnStop,
+				})
+			},
+		),
+		fx.Decorate(func(log *zap.Logger) *zap.Logger {
+			return log.Named("tracing")
+		}),
+	)
+}
@@ -0,0 +1,24 @@
+package tracing
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNewExporter(t *testing.T) {
+	for _, name := range []string{"", ExporterNone} {
+		exp, err := newExporter(name, nil)
+		if err != nil || exp != nil {
+			t.Fatalf("newExporter(%q) = %v, %v; want disabled", name, exp, err)
+		}
+	}
+
+	exp, err := newExporter(ExporterStdout, &bytes.Buffer{})
+	if err != nil || exp == nil {
+		t.Fatalf("newExporter(stdout) = %v, %v", exp, err)
+	}
+
+	if _, err := newExporter("zipkin", nil); err == nil {
+		t.Fatalf("expected error for unknown exporter")
+	}
+}
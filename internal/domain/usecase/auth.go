@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pr-service/internal/domain/auth"
+	"pr-service/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+func (u *Usecase) CreateAPIKey(ctx context.Context, req entities.CreateAPIKeyRequest) (entities.CreatedAPIKey, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.CreateAPIKey")
+	defer span.End()
+
+	if err := u.authorizeAdmin(ctx); err != nil {
+		return entities.CreatedAPIKey{}, err
+	}
+
+	var userID *string
+	if req.UserID != "" {
+		if _, err := u.repo.GetUserByID(ctx, req.UserID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return entities.CreatedAPIKey{}, &entities.DomainError{
+					Code:    entities.ErrorCodeNotFound,
+					Message: "resource not found",
+				}
+			}
+			u.log.Error("failed to get user", zap.Error(err))
+			return entities.CreatedAPIKey{}, err
+		}
+		userID = &req.UserID
+	}
+
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		u.log.Error("failed to generate api key", zap.Error(err))
+		return entities.CreatedAPIKey{}, err
+	}
+
+	created, err := u.repo.CreateAPIKey(ctx, req.Name, prefix, hash, userID)
+	if err != nil {
+		u.log.Error("failed to create api key", zap.Error(err))
+		return entities.CreatedAPIKey{}, err
+	}
+
+	u.log.Info("api key created", zap.Int64("api_key_id", created.ID), zap.String("name", created.Name))
+	return entities.CreatedAPIKey{APIKey: created, Key: key}, nil
+}
+
+func (u *Usecase) ListAPIKeys(ctx context.Context) ([]entities.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.ListAPIKeys")
+	defer span.End()
+
+	if err := u.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	keys, err := u.repo.ListAPIKeys(ctx)
+	if err != nil {
+		u.log.Error("failed to list api keys", zap.Error(err))
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (u *Usecase) RevokeAPIKey(ctx context.Context, id int64) error {
+	ctx, span := tracer.Start(ctx, "Usecase.RevokeAPIKey")
+	defer span.End()
+
+	if err := u.authorizeAdmin(ctx); err != nil {
+		return err
+	}
+
+	if err := u.repo.RevokeAPIKey(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "resource not found",
+			}
+		}
+		u.log.Error("failed to revoke api key", zap.Error(err))
+		return err
+	}
+
+	u.log.Info("api key revoked", zap.Int64("api_key_id", id))
+	return nil
+}
+
+// Authenticate resolves a bearer credential, either an API key or a JWT, to
+// the calling principal. A key bound to a user and a JWT whose subject is a
+// known user act with that user's role; anything else is read-only. The
+// tenant comes from the key or from the token's tenant claim.
+func (u *Usecase) Authenticate(ctx context.Context, credential string) (entities.Principal, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.Authenticate")
+	defer span.End()
+
+	unauthorized := &entities.DomainError{
+		Code:    entities.ErrorCodeUnauthorized,
+		Message: "invalid or missing credentials",
+	}
+
+	if credential == "" {
+		return entities.Principal{}, unauthorized
+	}
+
+	if auth.IsAPIKey(credential) {
+		key, err := u.repo.UseAPIKey(ctx, auth.HashAPIKey(credential))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return entities.Principal{}, unauthorized
+			}
+			u.log.Error("failed to look up api key", zap.Error(err))
+			return entities.Principal{}, err
+		}
+		p := entities.Principal{
+			Subject:  key.Name,
+			Method:   entities.AuthMethodAPIKey,
+			APIKeyID: key.ID,
+			TenantID: key.TenantID,
+			Role:     entities.RoleReadOnly,
+		}
+		if key.UserID == nil {
+			return p, nil
+		}
+		ctx = entities.WithTenant(ctx, key.TenantID)
+		ur, err := u.repo.GetUserRole(ctx, *key.UserID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return entities.Principal{}, unauthorized
+			}
+			u.log.Error("failed to get user role", zap.Error(err))
+			return entities.Principal{}, err
+		}
+		p.UserID, p.TeamName, p.Role = ur.UserID, ur.TeamName, ur.Role
+		return p, nil
+	}
+
+	claims, err := u.jwt.Verify(credential)
+	if err != nil {
+		u.log.Debug("rejected bearer token", zap.Error(err))
+		return entities.Principal{}, unauthorized
+	}
+	tenantID := claims.Tenant
+	if tenantID == "" {
+		tenantID = entities.DefaultTenant
+	}
+	if err := u.CheckTenant(ctx, tenantID); err != nil {
+		return entities.Principal{}, err
+	}
+	p := entities.Principal{
+		Subject:  claims.Subject,
+		Method:   entities.AuthMethodJWT,
+		TenantID: tenantID,
+		Role:     entities.RoleReadOnly,
+	}
+	ctx = entities.WithTenant(ctx, tenantID)
+	ur, err := u.repo.GetUserRole(ctx, claims.Subject)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return p, nil
+		}
+		u.log.Error("failed to get user role", zap.Error(err))
+		return entities.Principal{}, err
+	}
+	p.UserID, p.TeamName, p.Role = ur.UserID, ur.TeamName, ur.Role
+	return p, nil
+}
+
+// IdentifyUser builds the principal for a user named by a trusted header in
+// the tenant of ctx. Like Authenticate it is the identification step itself
+// and checks no role.
+func (u *Usecase) IdentifyUser(ctx context.Context, userID string) (entities.Principal, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.IdentifyUser")
+	defer span.End()
+
+	ur, err := u.repo.GetUserRole(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Principal{}, &entities.DomainError{
+				Code:    entities.ErrorCodeUnauthorized,
+				Message: "unknown user",
+			}
+		}
+		u.log.Error("failed to get user role", zap.Error(err))
+		return entities.Principal{}, err
+	}
+	return entities.Principal{
+		Subject:  ur.UserID,
+		Method:   entities.AuthMethodHeader,
+		TenantID: entities.TenantFromContext(ctx),
+		UserID:   ur.UserID,
+		TeamName: ur.TeamName,
+		Role:     ur.Role,
+	}, nil
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pr-service/internal/domain/entities"
+	"pr-service/internal/domain/metrics"
+	"pr-service/internal/domain/repository/postgres"
+
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+const unavailabilityBatchSize = 100
+
+func (u *Usecase) CreateUnavailability(
+	ctx context.Context,
+	req entities.CreateUnavailabilityRequest,
+) (entities.Unavailability, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.CreateUnavailability")
+	defer span.End()
+
+	if err := u.authorizeUser(ctx, req.UserID, true); err != nil {
+		return entities.Unavailability{}, err
+	}
+
+	if _, err := u.repo.GetUserByID(ctx, req.UserID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Unavailability{}, &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "resource not found",
+			}
+		}
+		u.log.Error("failed to get user", zap.Error(err))
+		return entities.Unavailability{}, err
+	}
+
+	ua, err := u.repo.CreateUnavailability(ctx, entities.Unavailability{
+		UserID:   req.UserID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		u.log.Error("failed to create unavailability", zap.Error(err))
+		return entities.Unavailability{}, err
+	}
+	return ua, nil
+}
+
+func (u *Usecase) UpdateUnavailability(
+	ctx context.Context,
+	req entities.UpdateUnavailabilityRequest,
+) (entities.Unavailability, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.UpdateUnavailability")
+	defer span.End()
+
+	if err := u.authorizeUnavailability(ctx, req.ID); err != nil {
+		return entities.Unavailability{}, err
+	}
+
+	ua, err := u.repo.UpdateUnavailability(ctx, entities.Unavailability{
+		ID:       req.ID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Unavailability{}, &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "resource not found",
+			}
+		}
+		u.log.Error("failed to update unavailability", zap.Error(err))
+		return entities.Unavailability{}, err
+	}
+	return ua, nil
+}
+
+func (u *Usecase) DeleteUnavailability(ctx context.Context, id int64) error {
+	ctx, span := tracer.Start(ctx, "Usecase.DeleteUnavailability")
+	defer span.End()
+
+	if err := u.authorizeUnavailability(ctx, id); err != nil {
+		return err
+	}
+
+	if err := u.repo.DeleteUnavailability(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "resource not found",
+			}
+		}
+		u.log.Error("failed to delete unavailability", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (u *Usecase) ListUnavailability(ctx context.Context, userID string) ([]entities.Unavailability, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.ListUnavailability")
+	defer span.End()
+
+	if err := u.authorizeRead(ctx); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.repo.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "resource not found",
+			}
+		}
+		u.log.Error("failed to get user", zap.Error(err))
+		return nil, err
+	}
+
+	periods, err := u.repo.ListUnavailability(ctx, userID)
+	if err != nil {
+		u.log.Error("failed to list unavailability", zap.Error(err))
+		return nil, err
+	}
+	return periods, nil
+}
+
+// ReassignUnavailableReviewers hands over the open reviews of everyone whose
+// absence has just begun. A period without a replacement candidate is retried
+// on the next run.
+func (u *Usecase) ReassignUnavailableReviewers(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.ReassignUnavailableReviewers")
+	defer span.End()
+
+	if err := u.authorizeAdmin(ctx); err != nil {
+		return 0, err
+	}
+
+	periods, err := u.repo.ListStartedUnavailability(ctx, unavailabilityBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, ua := range periods {
+		log := u.log.With(
+			zap.String("tenant_id", ua.TenantID),
+			zap.Int64("unavailability_id", ua.ID),
+			zap.String("user_id", ua.UserID),
+		)
+		// Periods are listed across tenants.
+		ctx := entities.WithTenant(ctx, ua.TenantID)
+
+		user, err := u.repo.GetUserByID(ctx, ua.UserID)
+		if err != nil {
+			log.Error("failed to get unavailable user", zap.Error(err))
+			continue
+		}
+		settings, err := u.repo.GetTeamSettings(ctx, user.TeamName)
+		if err != nil {
+			log.Error("failed to get team settings", zap.Error(err))
+			continue
+		}
+
+		n, err := u.repo.ReassignForUnavailability(ctx, ua, user.TeamName, settings.FallbackTeams)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			continue
+		case errors.Is(err, postgres.ErrNoReplacementCandidate):
+			u.metrics.NoCandidate("unavailability")
+			log.Warn("no replacement for unavailable reviewer, will retry")
+			continue
+		case err != nil:
+			log.Error("failed to reassign reviews of unavailable user", zap.Error(err))
+			continue
+		}
+
+		if n > 0 {
+			u.metrics.ReviewersReassigned(metrics.ReassignSourceUnavailable, n)
+			log.Info("reassigned reviews of unavailable user", zap.Int("reassigned", n))
+		}
+		total += n
+	}
+	return total, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"pr-service/internal/domain/entities"
+	"pr-service/internal/domain/metrics"
 	"pr-service/internal/domain/repository/postgres"
 
 	"go.uber.org/zap"
@@ -14,6 +15,13 @@ func (u *Usecase) BulkDeactivateTeamUsers(
 	teamName string,
 	userIDs []string,
 ) (entities.BulkDeactivateResult, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.BulkDeactivateTeamUsers")
+	defer span.End()
+
+	if err := u.authorizeTeam(ctx, teamName); err != nil {
+		return entities.BulkDeactivateResult{}, err
+	}
+
 	exists, err := u.repo.TeamExists(ctx, teamName)
 	if err != nil {
 		u.log.Error("failed to check team exists before bulk deactivate", zap.Error(err))
@@ -26,17 +34,25 @@ func (u *Usecase) BulkDeactivateTeamUsers(
 		}
 	}
 
-	res, err := u.repo.BulkDeactivateTeamUsers(ctx, teamName, userIDs)
+	settings, err := u.repo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		u.log.Error("failed to get team settings before bulk deactivate", zap.Error(err))
+		return entities.BulkDeactivateResult{}, err
+	}
+
+	res, err := u.repo.BulkDeactivateTeamUsers(ctx, teamName, userIDs, settings.FallbackTeams)
 	if err != nil {
 		if errors.Is(err, postgres.ErrNoReplacementCandidate) {
+			u.metrics.NoCandidate("bulk_deactivate")
 			return entities.BulkDeactivateResult{}, &entities.DomainError{
 				Code:    entities.ErrorCodeNoCandidate,
-				Message: "no active replacement candidate in team",
+				Message: "no active replacement candidate in team or its fallback teams",
 			}
 		}
 		u.log.Error("failed to bulk deactivate users", zap.Error(err))
 		return entities.BulkDeactivateResult{}, err
 	}
 
+	u.metrics.ReviewersReassigned(metrics.ReassignSourceDeactivate, res.ReassignedCount)
 	return res, nil
 }
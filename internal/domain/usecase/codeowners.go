@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pr-service/internal/domain/codeowners"
+	"pr-service/internal/domain/entities"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+func (u *Usecase) UploadCodeowners(ctx context.Context, req entities.UploadCodeownersRequest) (entities.TeamCodeowners, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.UploadCodeowners")
+	defer span.End()
+
+	if err := u.authorizeTeam(ctx, req.TeamName); err != nil {
+		return entities.TeamCodeowners{}, err
+	}
+
+	exists, err := u.repo.TeamExists(ctx, req.TeamName)
+	if err != nil {
+		u.log.Error("failed to check team exists", zap.Error(err))
+		return entities.TeamCodeowners{}, err
+	}
+	if !exists {
+		return entities.TeamCodeowners{}, &entities.DomainError{
+			Code:    entities.ErrorCodeNotFound,
+			Message: "resource not found",
+		}
+	}
+
+	rules, err := codeowners.Parse(req.Content)
+	if err != nil {
+		return entities.TeamCodeowners{}, &entities.DomainError{
+			Code:    entities.ErrorCodeInvalidOwners,
+			Message: err.Error(),
+		}
+	}
+
+	co, err := u.repo.UpsertTeamCodeowners(ctx, req.TeamName, req.Content)
+	if err != nil {
+		u.log.Error("failed to save codeowners", zap.Error(err))
+		return entities.TeamCodeowners{}, err
+	}
+	co.Rules = rules.Len()
+	return co, nil
+}
+
+func (u *Usecase) GetCodeowners(ctx context.Context, teamName string) (entities.TeamCodeowners, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.GetCodeowners")
+	defer span.End()
+
+	if err := u.authorizeRead(ctx); err != nil {
+		return entities.TeamCodeowners{}, err
+	}
+
+	co, err := u.repo.GetTeamCodeowners(ctx, teamName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.TeamCodeowners{}, &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "resource not found",
+			}
+		}
+		u.log.Error("failed to get codeowners", zap.Error(err))
+		return entities.TeamCodeowners{}, err
+	}
+
+	rules, err := codeowners.Parse(co.Content)
+	if err != nil {
+		u.log.Warn("stored codeowners no longer parse", zap.String("team_name", teamName), zap.Error(err))
+	}
+	co.Rules = rules.Len()
+	return co, nil
+}
+
+// splitByOwnership partitions candidates into owners of any of the changed
+// files (matched by user_id or username) and everyone else.
+func (u *Usecase) splitByOwnership(
+	ctx context.Context,
+	teamName string,
+	candidates []entities.User,
+	changedFiles []string,
+) (owners, others []entities.User, err error) {
+	if len(changedFiles) == 0 || len(candidates) == 0 {
+		return nil, candidates, nil
+	}
+
+	co, err := u.repo.GetTeamCodeowners(ctx, teamName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, candidates, nil
+		}
+		u.log.Error("failed to get codeowners", zap.Error(err))
+		return nil, nil, err
+	}
+
+	rules, err := codeowners.Parse(co.Content)
+	if err != nil {
+		u.log.Warn("ignoring unparsable codeowners", zap.String("team_name", teamName), zap.Error(err))
+		return nil, candidates, nil
+	}
+
+	owned := make(map[string]bool)
+	for _, path := range changedFiles {
+		for _, owner := range rules.Owners(path) {
+			owned[strings.ToLower(owner)] = true
+		}
+	}
+
+	for _, c := range candidates {
+		if owned[strings.ToLower(c.UserID)] || owned[strings.ToLower(c.Username)] {
+			owners = append(owners, c)
+		} else {
+			others = append(others, c)
+		}
+	}
+	return owners, others, nil
+}
+
+func normalizeChangedFiles(files []string) []string {
+	res := make([]string, 0, len(files))
+	seen := make(map[string]bool, len(files))
+	for _, f := range files {
+		f = strings.TrimPrefix(strings.TrimSpace(f), "/")
+		if f == "" || seen[f] {
+			continue
+		}
+		seen[f] = true
+		res = append(res, f)
+	}
+	return res
+}
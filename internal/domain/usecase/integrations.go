@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pr-service/internal/domain/entities"
+	"pr-service/internal/domain/repository/postgres"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+// LinkIdentity is admin-only: a linked login receives the external user's PR
+// events, and the service cannot verify who owns it.
+func (u *Usecase) LinkIdentity(ctx context.Context, req entities.LinkIdentityRequest) (entities.UserIdentity, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.LinkIdentity")
+	defer span.End()
+
+	if err := u.authorizeAdmin(ctx); err != nil {
+		return entities.UserIdentity{}, err
+	}
+
+	if _, err := u.repo.GetUserByID(ctx, req.UserID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.UserIdentity{}, &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "resource not found",
+			}
+		}
+		u.log.Error("failed to get user", zap.Error(err))
+		return entities.UserIdentity{}, err
+	}
+
+	identity := entities.UserIdentity{
+		Provider: req.Provider,
+		Login:    strings.ToLower(req.Login),
+		UserID:   req.UserID,
+	}
+	if err := u.repo.LinkIdentity(ctx, identity); err != nil {
+		if errors.Is(err, postgres.ErrIdentityLinked) {
+			return entities.UserIdentity{}, &entities.DomainError{
+				Code:    entities.ErrorCodeIdentityLinked,
+				Message: req.Provider + " login " + req.Login + " is linked to another user",
+			}
+		}
+		u.log.Error("failed to link identity", zap.Error(err))
+		return entities.UserIdentity{}, err
+	}
+	return identity, nil
+}
+
+func (u *Usecase) resolveIdentity(ctx context.Context, provider, login string) (string, error) {
+	userID, err := u.repo.GetUserIDByIdentity(ctx, provider, strings.ToLower(login))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			u.log.Warn("rejecting event from unknown identity",
+				zap.String("provider", provider),
+				zap.String("login", login),
+			)
+			return "", &entities.DomainError{
+				Code:    entities.ErrorCodeUnknownIdentity,
+				Message: provider + " login " + login + " is not linked to a user",
+			}
+		}
+		u.log.Error("failed to resolve identity", zap.Error(err))
+		return "", err
+	}
+	return userID, nil
+}
+
+var externalActionStatus = map[string]string{
+	entities.ExternalActionReady:    entities.PullRequestStatusOpen,
+	entities.ExternalActionClosed:   entities.PullRequestStatusClosed,
+	entities.ExternalActionReopened: entities.PullRequestStatusOpen,
+}
+
+func (u *Usecase) ApplyExternalPullRequestEvent(
+	ctx context.Context,
+	ev entities.ExternalPullRequestEvent,
+) (entities.ExternalEventResult, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.ApplyExternalPullRequestEvent")
+	defer span.End()
+
+	if err := u.authorizeAdmin(ctx); err != nil {
+		return entities.ExternalEventResult{}, err
+	}
+
+	ctx = entities.WithActor(ctx, ev.Provider+":"+ev.SenderLogin)
+	res := entities.ExternalEventResult{Action: ev.Action}
+
+	if ev.Action == entities.ExternalActionOpened {
+		authorID, err := u.resolveIdentity(ctx, ev.Provider, ev.AuthorLogin)
+		if err != nil {
+			return entities.ExternalEventResult{}, err
+		}
+
+		pr, err := u.CreatePullRequest(ctx, entities.CreatePullRequestRequest{
+			PullRequestID:   ev.PullRequestID,
+			PullRequestName: ev.Title,
+			AuthorID:        authorID,
+			Draft:           ev.Draft,
+		})
+		var derr *entities.DomainError
+		if errors.As(err, &derr) && derr.Code == entities.ErrorCodePRExists {
+			res.Ignored = true
+			pr, _, err = u.getPullRequest(ctx, ev.PullRequestID)
+		}
+		if err != nil {
+			return entities.ExternalEventResult{}, err
+		}
+		res.PullRequest = &pr
+		return res, nil
+	}
+
+	current, _, err := u.getPullRequest(ctx, ev.PullRequestID)
+	if err != nil {
+		return entities.ExternalEventResult{}, err
+	}
+	if status, ok := externalActionStatus[ev.Action]; ok && current.Status == status {
+		res.Ignored = true
+		res.PullRequest = &current
+		return res, nil
+	}
+
+	var pr entities.PullRequest
+	switch ev.Action {
+	case entities.ExternalActionReady:
+		pr, err = u.MarkPullRequestReady(ctx, ev.PullRequestID)
+	case entities.ExternalActionClosed:
+		pr, err = u.ClosePullRequest(ctx, ev.PullRequestID)
+	case entities.ExternalActionReopened:
+		pr, err = u.ReopenPullRequest(ctx, ev.PullRequestID)
+	case entities.ExternalActionMerged:
+		pr, err = u.MergePullRequest(ctx, ev.PullRequestID, true)
+	default:
+		res.Ignored = true
+		return res, nil
+	}
+	if err != nil {
+		return entities.ExternalEventResult{}, err
+	}
+
+	res.PullRequest = &pr
+	return res, nil
+}
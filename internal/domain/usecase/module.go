@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"pr-service/internal/domain/auth"
+
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -10,6 +12,7 @@ func New() fx.Option {
 		"usecase",
 		fx.Provide(
 			NewUsecase,
+			auth.NewJWTVerifier,
 		),
 		fx.Decorate(func(log *zap.Logger) *zap.Logger {
 			return log.Named("usecase")
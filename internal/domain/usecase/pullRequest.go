@@ -3,8 +3,10 @@ package usecase
 import (
 	"context"
 	"errors"
-	"math/rand"
+	"fmt"
 	"pr-service/internal/domain/entities"
+	"pr-service/internal/domain/metrics"
+	"slices"
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
@@ -12,6 +14,13 @@ import (
 )
 
 func (u *Usecase) CreatePullRequest(ctx context.Context, req entities.CreatePullRequestRequest) (entities.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.CreatePullRequest")
+	defer span.End()
+
+	if err := u.authorizeUser(ctx, req.AuthorID, true); err != nil {
+		return entities.PullRequest{}, err
+	}
+
 	author, err := u.repo.GetUserByID(ctx, req.AuthorID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -24,22 +33,29 @@ func (u *Usecase) CreatePullRequest(ctx context.Context, req entities.CreatePull
 		return entities.PullRequest{}, err
 	}
 
-	candidates, err := u.repo.ListTeamActiveUsersExcept(ctx, author.TeamName, author.UserID)
-	if err != nil {
-		u.log.Error("failed to list reviewer candidates", zap.Error(err))
-		return entities.PullRequest{}, err
-	}
-
-	reviewers := pickReviewers(candidates, 2)
-
 	pr := entities.PullRequest{
 		PullRequestID:   req.PullRequestID,
 		PullRequestName: req.PullRequestName,
 		AuthorID:        req.AuthorID,
-		Status:          "OPEN",
+		Status:          entities.PullRequestStatusOpen,
+		ChangedFiles:    normalizeChangedFiles(req.ChangedFiles),
 	}
 
-	if err := u.repo.CreatePullRequest(ctx, pr, reviewers); err != nil {
+	var (
+		reviewers    []string
+		underStaffed bool
+		rot          = &rotations{}
+	)
+	if req.Draft {
+		pr.Status = entities.PullRequestStatusDraft
+	} else {
+		reviewers, underStaffed, err = u.selectReviewers(ctx, author, pr.ChangedFiles, rot)
+		if err != nil {
+			return entities.PullRequest{}, err
+		}
+	}
+
+	if err := u.repo.CreatePullRequest(ctx, pr, reviewers, rot.rotation()); err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
 			return entities.PullRequest{}, &entities.DomainError{
@@ -57,12 +73,125 @@ func (u *Usecase) CreatePullRequest(ctx context.Context, req entities.CreatePull
 		return entities.PullRequest{}, err
 	}
 	created.AssignedReviewers = assigned
+	created.UnderStaffed = underStaffed
+	u.metrics.PullRequestCreated()
 
 	return created, nil
 }
 
-func (u *Usecase) MergePullRequest(ctx context.Context, prID string) (entities.PullRequest, error) {
-	pr, reviewers, err := u.repo.MarkPullRequestMerged(ctx, prID)
+// selectReviewers records round_robin picks in rot; the caller stores them
+// with the assignment.
+func (u *Usecase) selectReviewers(
+	ctx context.Context,
+	author entities.User,
+	changedFiles []string,
+	rot *rotations,
+) ([]string, bool, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.selectReviewers")
+	defer span.End()
+
+	candidates, err := u.repo.ListTeamActiveUsersExcept(ctx, author.TeamName, author.UserID)
+	if err != nil {
+		u.log.Error("failed to list reviewer candidates", zap.Error(err))
+		return nil, false, err
+	}
+
+	settings, err := u.repo.GetTeamSettings(ctx, author.TeamName)
+	if err != nil {
+		u.log.Error("failed to get team settings", zap.Error(err))
+		return nil, false, err
+	}
+
+	owners, others, err := u.splitByOwnership(ctx, author.TeamName, candidates, changedFiles)
+	if err != nil {
+		return nil, false, err
+	}
+
+	reviewers, err := u.pick(ctx, settings, author.TeamName, owners, settings.ReviewersCount, rot)
+	if err != nil {
+		u.log.Error("failed to pick code owners", zap.Error(err))
+		return nil, false, err
+	}
+
+	if rest := settings.ReviewersCount - len(reviewers); rest > 0 {
+		more, err := u.pick(ctx, settings, author.TeamName, others, rest, rot)
+		if err != nil {
+			u.log.Error("failed to pick reviewers", zap.Error(err))
+			return nil, false, err
+		}
+		reviewers = append(reviewers, more...)
+	}
+
+	if rest := settings.ReviewersCount - len(reviewers); rest > 0 {
+		more, err := u.pickFallback(ctx, settings, author.UserID, reviewers, rest, rot)
+		if err != nil {
+			return nil, false, err
+		}
+		reviewers = append(reviewers, more...)
+	}
+	underStaffed := len(reviewers) < settings.ReviewersCount
+	if underStaffed {
+		u.log.Warn("not enough reviewers under capacity",
+			zap.String("team_name", author.TeamName),
+			zap.Int("wanted", settings.ReviewersCount),
+			zap.Int("assigned", len(reviewers)),
+		)
+	}
+	return reviewers, underStaffed, nil
+}
+
+// pickFallback picks up to limit reviewers from the team's fallback teams in
+// the configured order, skipping exceptUserID and anyone already chosen.
+func (u *Usecase) pickFallback(
+	ctx context.Context,
+	settings entities.TeamSettings,
+	exceptUserID string,
+	chosen []string,
+	limit int,
+	rot *rotations,
+) ([]string, error) {
+	var res []string
+	for _, team := range settings.FallbackTeams {
+		if limit <= 0 {
+			break
+		}
+
+		candidates, err := u.repo.ListTeamActiveUsersExcept(ctx, team, exceptUserID)
+		if err != nil {
+			u.log.Error("failed to list fallback candidates", zap.Error(err))
+			return nil, err
+		}
+		candidates = excludeUsers(candidates, chosen, res)
+
+		picked, err := u.pick(ctx, settings, team, candidates, limit, rot)
+		if err != nil {
+			u.log.Error("failed to pick fallback reviewers", zap.Error(err))
+			return nil, err
+		}
+		if len(picked) > 0 {
+			u.log.Info("assigned reviewers from fallback team",
+				zap.String("team_name", settings.TeamName),
+				zap.String("fallback_team", team),
+				zap.Strings("reviewers", picked),
+			)
+		}
+		res = append(res, picked...)
+		limit -= len(picked)
+	}
+	return res, nil
+}
+
+func (u *Usecase) MergePullRequest(ctx context.Context, prID string, force bool) (entities.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.MergePullRequest")
+	defer span.End()
+
+	if force {
+		if err := u.authorizeAdmin(ctx); err != nil {
+			return entities.PullRequest{}, err
+		}
+	}
+
+	current, _, err := u.repo.GetPullRequest(ctx, prID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entities.PullRequest{}, &entities.DomainError{
@@ -70,14 +199,118 @@ func (u *Usecase) MergePullRequest(ctx context.Context, prID string) (entities.P
 				Message: "resource not found",
 			}
 		}
+		u.log.Error("failed to get pull request", zap.Error(err))
+		return entities.PullRequest{}, err
+	}
+	if err := u.authorizeUser(ctx, current.AuthorID, true); err != nil {
+		return entities.PullRequest{}, err
+	}
+
+	var check func(reviews []entities.ReviewerState) error
+	if current.Status != entities.PullRequestStatusMerged {
+		if err := checkTransition(current, entities.PullRequestStatusMerged); err != nil {
+			return entities.PullRequest{}, err
+		}
+		settings, err := u.mergeSettings(ctx, current)
+		if err != nil {
+			return entities.PullRequest{}, err
+		}
+		check = func(reviews []entities.ReviewerState) error {
+			err := checkMergePolicy(settings, reviews)
+			if err != nil && force {
+				u.log.Warn("force merging pull request",
+					zap.String("pull_request_id", prID),
+					zap.Error(err),
+				)
+				return nil
+			}
+			return err
+		}
+	}
+
+	pr, reviewers, err := u.repo.MarkPullRequestMerged(ctx, prID, check)
+	if err != nil {
+		var derr *entities.DomainError
+		if errors.As(err, &derr) {
+			return entities.PullRequest{}, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.PullRequest{}, &entities.DomainError{
+				Code:    entities.ErrorCodeInvalidStatus,
+				Message: "PR status changed concurrently",
+			}
+		}
 		u.log.Error("failed to merge pull request", zap.Error(err))
 		return entities.PullRequest{}, err
 	}
+	if current.Status != entities.PullRequestStatusMerged {
+		u.metrics.PullRequestMerged()
+	}
 	pr.AssignedReviewers = reviewers
 	return pr, nil
 }
 
+// mergeSettings returns the settings of the author's team, which hold the
+// merge policy.
+func (u *Usecase) mergeSettings(ctx context.Context, pr entities.PullRequest) (entities.TeamSettings, error) {
+	author, err := u.repo.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		u.log.Error("failed to get author", zap.Error(err))
+		return entities.TeamSettings{}, err
+	}
+
+	settings, err := u.repo.GetTeamSettings(ctx, author.TeamName)
+	if err != nil {
+		u.log.Error("failed to get team settings", zap.Error(err))
+		return entities.TeamSettings{}, err
+	}
+	return settings, nil
+}
+
+// checkMergePolicy runs inside the merge transaction on the locked reviews.
+func checkMergePolicy(settings entities.TeamSettings, reviews []entities.ReviewerState) error {
+	approvals := 0
+	for _, rs := range reviews {
+		if rs.State == entities.ReviewStateApproved {
+			approvals++
+		}
+	}
+
+	if approvals < settings.MinApprovals {
+		return &entities.DomainError{
+			Code:    entities.ErrorCodeNotApproved,
+			Message: fmt.Sprintf("PR has %d of %d required approvals", approvals, settings.MinApprovals),
+		}
+	}
+	if settings.RequireAllApproved && approvals < len(reviews) {
+		return &entities.DomainError{
+			Code:    entities.ErrorCodeNotApproved,
+			Message: "not all assigned reviewers approved PR",
+		}
+	}
+	return nil
+}
+
 func (u *Usecase) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (entities.PullRequest, string, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.ReassignReviewer")
+	defer span.End()
+
+	if err := u.authorizeUser(ctx, oldReviewerID, true); err != nil {
+		return entities.PullRequest{}, "", err
+	}
+
+	pr, newReviewer, err := u.reassignReviewer(ctx, prID, oldReviewerID, "manual reassign")
+	if err != nil {
+		return entities.PullRequest{}, "", err
+	}
+	u.metrics.ReviewersReassigned(metrics.ReassignSourceManual, 1)
+	return pr, newReviewer, nil
+}
+
+func (u *Usecase) reassignReviewer(
+	ctx context.Context,
+	prID, oldReviewerID, reason string,
+) (entities.PullRequest, string, error) {
 	pr, reviewers, err := u.repo.GetPullRequest(ctx, prID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -90,13 +323,18 @@ func (u *Usecase) ReassignReviewer(ctx context.Context, prID, oldReviewerID stri
 		return entities.PullRequest{}, "", err
 	}
 
-	if pr.Status == "MERGED" {
-
+	if pr.Status == entities.PullRequestStatusMerged {
 		return entities.PullRequest{}, "", &entities.DomainError{
 			Code:    entities.ErrorCodePRMerged,
 			Message: "cannot reassign on merged PR",
 		}
 	}
+	if pr.Status != entities.PullRequestStatusOpen {
+		return entities.PullRequest{}, "", &entities.DomainError{
+			Code:    entities.ErrorCodeInvalidStatus,
+			Message: "cannot reassign on " + pr.Status + " PR",
+		}
+	}
 
 	assignedSet := make(map[string]struct{}, len(reviewers))
 	for _, id := range reviewers {
@@ -138,16 +376,39 @@ func (u *Usecase) ReassignReviewer(ctx context.Context, prID, oldReviewerID stri
 		filtered = append(filtered, cand)
 	}
 
-	if len(filtered) == 0 {
+	settings, err := u.repo.GetTeamSettings(ctx, oldUser.TeamName)
+	if err != nil {
+		u.log.Error("failed to get team settings", zap.Error(err))
+		return entities.PullRequest{}, "", err
+	}
+
+	var (
+		picked []string
+		rot    = &rotations{}
+	)
+	if len(filtered) > 0 {
+		picked, err = u.pick(ctx, settings, oldUser.TeamName, filtered, 1, rot)
+		if err != nil {
+			u.log.Error("failed to pick replacement reviewer", zap.Error(err))
+			return entities.PullRequest{}, "", err
+		}
+	} else {
+		picked, err = u.pickFallback(ctx, settings, pr.AuthorID, reviewers, 1, rot)
+		if err != nil {
+			return entities.PullRequest{}, "", err
+		}
+		reason += " (fallback team)"
+	}
+
+	if len(picked) == 0 {
+		u.metrics.NoCandidate("reassign")
 		return entities.PullRequest{}, "", &entities.DomainError{
 			Code:    entities.ErrorCodeNoCandidate,
 			Message: "no active replacement candidate in team",
 		}
 	}
-
-	newReviewer := filtered[rand.Intn(len(filtered))].UserID
-
-	if err := u.repo.ReplaceReviewer(ctx, prID, oldReviewerID, newReviewer); err != nil {
+	newReviewer, err := u.repo.ReplaceReviewer(ctx, prID, oldReviewerID, picked[0], reason, rot.rotation())
+	if err != nil {
 		u.log.Error("failed to replace reviewer", zap.Error(err))
 		return entities.PullRequest{}, "", err
 	}
@@ -162,7 +423,81 @@ func (u *Usecase) ReassignReviewer(ctx context.Context, prID, oldReviewerID stri
 	return updated, newReviewer, nil
 }
 
-func (u *Usecase) GetUserReviews(ctx context.Context, userID string) (entities.GetUserReviewsResponse, error) {
+func (u *Usecase) SubmitReview(ctx context.Context, req entities.SubmitReviewRequest) (entities.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.SubmitReview")
+	defer span.End()
+
+	if err := u.authorizeSelf(ctx, req.ReviewerID); err != nil {
+		return entities.PullRequest{}, err
+	}
+
+	pr, reviewers, err := u.repo.GetPullRequest(ctx, req.PullRequestID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.PullRequest{}, &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "resource not found",
+			}
+		}
+		u.log.Error("failed to get pull request", zap.Error(err))
+		return entities.PullRequest{}, err
+	}
+
+	if pr.Status == entities.PullRequestStatusMerged {
+		return entities.PullRequest{}, &entities.DomainError{
+			Code:    entities.ErrorCodePRMerged,
+			Message: "cannot review merged PR",
+		}
+	}
+	if pr.Status != entities.PullRequestStatusOpen {
+		return entities.PullRequest{}, &entities.DomainError{
+			Code:    entities.ErrorCodeInvalidStatus,
+			Message: "cannot review " + pr.Status + " PR",
+		}
+	}
+
+	if !slices.Contains(reviewers, req.ReviewerID) {
+		return entities.PullRequest{}, &entities.DomainError{
+			Code:    entities.ErrorCodeNotAssigned,
+			Message: "reviewer is not assigned to this PR",
+		}
+	}
+
+	if err := u.repo.SetReviewState(ctx, req.PullRequestID, req.ReviewerID, req.State); err != nil {
+		u.log.Error("failed to set review state", zap.Error(err))
+		return entities.PullRequest{}, err
+	}
+
+	updated, updatedReviewers, err := u.repo.GetPullRequest(ctx, req.PullRequestID)
+	if err != nil {
+		u.log.Error("failed to reload pull request after review", zap.Error(err))
+		return entities.PullRequest{}, err
+	}
+	updated.AssignedReviewers = updatedReviewers
+
+	return updated, nil
+}
+
+func (u *Usecase) ApprovePullRequest(ctx context.Context, prID, reviewerID string) (entities.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.ApprovePullRequest")
+	defer span.End()
+
+	// SubmitReview checks the caller.
+	return u.SubmitReview(ctx, entities.SubmitReviewRequest{
+		PullRequestID: prID,
+		ReviewerID:    reviewerID,
+		State:         entities.ReviewStateApproved,
+	})
+}
+
+func (u *Usecase) GetUserReviews(ctx context.Context, userID string, onlyPending bool) (entities.GetUserReviewsResponse, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.GetUserReviews")
+	defer span.End()
+
+	if err := u.authorizeRead(ctx); err != nil {
+		return entities.GetUserReviewsResponse{}, err
+	}
+
 	if _, err := u.repo.GetUserByID(ctx, userID); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entities.GetUserReviewsResponse{}, &entities.DomainError{
@@ -174,14 +509,47 @@ func (u *Usecase) GetUserReviews(ctx context.Context, userID string) (entities.G
 		return entities.GetUserReviewsResponse{}, err
 	}
 
-	prs, err := u.repo.ListPullRequestsByReviewer(ctx, userID)
+	prs, err := u.repo.ListPullRequestsByReviewer(ctx, userID, onlyPending)
 	if err != nil {
 		u.log.Error("failed to list user PRs", zap.Error(err))
 		return entities.GetUserReviewsResponse{}, err
 	}
 
+	capacity, err := u.repo.GetReviewCapacity(ctx, userID)
+	if err != nil {
+		u.log.Error("failed to get review capacity", zap.Error(err))
+		return entities.GetUserReviewsResponse{}, err
+	}
+
 	return entities.GetUserReviewsResponse{
-		UserID:       userID,
-		PullRequests: prs,
+		UserID:         userID,
+		PullRequests:   prs,
+		OpenReviews:    capacity.OpenReviews,
+		MaxOpenReviews: capacity.MaxOpenReviews,
+		AtCapacity:     capacity.AtCapacity,
+	}, nil
+}
+
+func (u *Usecase) GetPullRequestHistory(ctx context.Context, prID string) (entities.PullRequestHistoryResponse, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.GetPullRequestHistory")
+	defer span.End()
+
+	if err := u.authorizeRead(ctx); err != nil {
+		return entities.PullRequestHistoryResponse{}, err
+	}
+
+	if _, _, err := u.getPullRequest(ctx, prID); err != nil {
+		return entities.PullRequestHistoryResponse{}, err
+	}
+
+	events, err := u.repo.ListAssignmentEvents(ctx, prID)
+	if err != nil {
+		u.log.Error("failed to list assignment events", zap.Error(err))
+		return entities.PullRequestHistoryResponse{}, err
+	}
+
+	return entities.PullRequestHistoryResponse{
+		PullRequestID: prID,
+		Events:        events,
 	}, nil
 }
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pr-service/internal/domain/entities"
+	"slices"
+
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+var pullRequestTransitions = map[string][]string{
+	entities.PullRequestStatusDraft:  {entities.PullRequestStatusOpen, entities.PullRequestStatusClosed},
+	entities.PullRequestStatusOpen:   {entities.PullRequestStatusMerged, entities.PullRequestStatusClosed},
+	entities.PullRequestStatusClosed: {entities.PullRequestStatusOpen},
+}
+
+func checkTransition(pr entities.PullRequest, to string) error {
+	if slices.Contains(pullRequestTransitions[pr.Status], to) {
+		return nil
+	}
+	if pr.Status == entities.PullRequestStatusMerged {
+		return &entities.DomainError{
+			Code:    entities.ErrorCodePRMerged,
+			Message: "PR is already merged",
+		}
+	}
+	return &entities.DomainError{
+		Code:    entities.ErrorCodeInvalidStatus,
+		Message: "cannot move PR from " + pr.Status + " to " + to,
+	}
+}
+
+func (u *Usecase) MarkPullRequestReady(ctx context.Context, prID string) (entities.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.MarkPullRequestReady")
+	defer span.End()
+
+	if err := u.authorizePullRequestAuthor(ctx, prID); err != nil {
+		return entities.PullRequest{}, err
+	}
+
+	return u.openPullRequest(ctx, prID, entities.PullRequestStatusDraft)
+}
+
+func (u *Usecase) ReopenPullRequest(ctx context.Context, prID string) (entities.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.ReopenPullRequest")
+	defer span.End()
+
+	if err := u.authorizePullRequestAuthor(ctx, prID); err != nil {
+		return entities.PullRequest{}, err
+	}
+
+	return u.openPullRequest(ctx, prID, entities.PullRequestStatusClosed)
+}
+
+func (u *Usecase) openPullRequest(ctx context.Context, prID, from string) (entities.PullRequest, error) {
+	pr, reviewers, err := u.getPullRequest(ctx, prID)
+	if err != nil {
+		return entities.PullRequest{}, err
+	}
+
+	if err := checkTransition(pr, entities.PullRequestStatusOpen); err != nil {
+		return entities.PullRequest{}, err
+	}
+	if pr.Status != from {
+		return entities.PullRequest{}, &entities.DomainError{
+			Code:    entities.ErrorCodeInvalidStatus,
+			Message: "PR is " + pr.Status + ", expected " + from,
+		}
+	}
+
+	var (
+		newReviewers []string
+		underStaffed bool
+		rot          = &rotations{}
+	)
+	if len(reviewers) == 0 {
+		author, err := u.repo.GetUserByID(ctx, pr.AuthorID)
+		if err != nil {
+			u.log.Error("failed to get author", zap.Error(err))
+			return entities.PullRequest{}, err
+		}
+		newReviewers, underStaffed, err = u.selectReviewers(ctx, author, pr.ChangedFiles, rot)
+		if err != nil {
+			return entities.PullRequest{}, err
+		}
+	}
+
+	if err := u.repo.OpenPullRequest(ctx, prID, from, newReviewers, rot.rotation()); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.PullRequest{}, &entities.DomainError{
+				Code:    entities.ErrorCodeInvalidStatus,
+				Message: "PR status changed concurrently",
+			}
+		}
+		u.log.Error("failed to open pull request", zap.Error(err))
+		return entities.PullRequest{}, err
+	}
+
+	updated, updatedReviewers, err := u.repo.GetPullRequest(ctx, prID)
+	if err != nil {
+		u.log.Error("failed to reload pull request after open", zap.Error(err))
+		return entities.PullRequest{}, err
+	}
+	updated.AssignedReviewers = updatedReviewers
+	updated.UnderStaffed = underStaffed
+
+	return updated, nil
+}
+
+func (u *Usecase) ClosePullRequest(ctx context.Context, prID string) (entities.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.ClosePullRequest")
+	defer span.End()
+
+	if err := u.authorizePullRequestAuthor(ctx, prID); err != nil {
+		return entities.PullRequest{}, err
+	}
+
+	pr, _, err := u.getPullRequest(ctx, prID)
+	if err != nil {
+		return entities.PullRequest{}, err
+	}
+
+	if err := checkTransition(pr, entities.PullRequestStatusClosed); err != nil {
+		return entities.PullRequest{}, err
+	}
+
+	closed, reviewers, err := u.repo.ClosePullRequest(ctx, prID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.PullRequest{}, &entities.DomainError{
+				Code:    entities.ErrorCodeInvalidStatus,
+				Message: "PR status changed concurrently",
+			}
+		}
+		u.log.Error("failed to close pull request", zap.Error(err))
+		return entities.PullRequest{}, err
+	}
+	closed.AssignedReviewers = reviewers
+
+	return closed, nil
+}
+
+func (u *Usecase) getPullRequest(ctx context.Context, prID string) (entities.PullRequest, []string, error) {
+	pr, reviewers, err := u.repo.GetPullRequest(ctx, prID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.PullRequest{}, nil, &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "resource not found",
+			}
+		}
+		u.log.Error("failed to get pull request", zap.Error(err))
+		return entities.PullRequest{}, nil, err
+	}
+	return pr, reviewers, nil
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pr-service/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+var allRoles = []string{
+	entities.RoleAdmin,
+	entities.RoleTeamLead,
+	entities.RoleDeveloper,
+	entities.RoleReadOnly,
+}
+
+func forbidden(message string) error {
+	return &entities.DomainError{
+		Code:    entities.ErrorCodeForbidden,
+		Message: message,
+	}
+}
+
+// caller returns the principal to check. With RBAC_ENABLED unset ok is false
+// and every check passes.
+func (u *Usecase) caller(ctx context.Context) (p entities.Principal, ok bool, err error) {
+	if !u.cfg.Auth.RBACEnabled {
+		return entities.Principal{}, false, nil
+	}
+	p, found := entities.PrincipalFromContext(ctx)
+	if !found || p.Role == "" {
+		return entities.Principal{}, true, forbidden("caller is not identified")
+	}
+	return p, true, nil
+}
+
+func (u *Usecase) requireRole(ctx context.Context, roles ...string) error {
+	p, ok, err := u.caller(ctx)
+	if !ok || err != nil {
+		return err
+	}
+	for _, r := range roles {
+		if p.Role == r {
+			return nil
+		}
+	}
+	return forbidden("role " + p.Role + " is not allowed to do this")
+}
+
+func (u *Usecase) authorizeRead(ctx context.Context) error {
+	return u.requireRole(ctx, allRoles...)
+}
+
+func (u *Usecase) authorizeAdmin(ctx context.Context) error {
+	return u.requireRole(ctx, entities.RoleAdmin)
+}
+
+// authorizeTeam allows admins and the team's leads.
+func (u *Usecase) authorizeTeam(ctx context.Context, teamName string) error {
+	p, ok, err := u.caller(ctx)
+	if !ok || err != nil {
+		return err
+	}
+	if !canManage(p, "", teamName, false) {
+		return forbidden("only an admin or the team lead can manage team " + teamName)
+	}
+	return nil
+}
+
+// authorizeUser allows admins, leads of the user's team and, when self is
+// set, the user themselves.
+func (u *Usecase) authorizeUser(ctx context.Context, userID string, self bool) error {
+	p, ok, err := u.caller(ctx)
+	if !ok || err != nil {
+		return err
+	}
+	if p.Role == entities.RoleAdmin || (self && p.UserID == userID && canManage(p, userID, "", true)) {
+		return nil
+	}
+	if p.Role != entities.RoleTeamLead {
+		return forbidden("not allowed to act on behalf of user " + userID)
+	}
+
+	user, err := u.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "resource not found",
+			}
+		}
+		u.log.Error("failed to get user", zap.Error(err))
+		return err
+	}
+	if !canManage(p, userID, user.TeamName, self) {
+		return forbidden("not allowed to act on behalf of user " + userID)
+	}
+	return nil
+}
+
+// authorizeSelf allows admins and the user themselves; used for actions
+// such as reviews that nobody should take on someone else's behalf.
+func (u *Usecase) authorizeSelf(ctx context.Context, userID string) error {
+	p, ok, err := u.caller(ctx)
+	if !ok || err != nil {
+		return err
+	}
+	if p.Role == entities.RoleAdmin || (p.UserID == userID && canManage(p, userID, "", true)) {
+		return nil
+	}
+	return forbidden("not allowed to act on behalf of user " + userID)
+}
+
+// authorizePullRequestAuthor applies authorizeUser to the PR's author.
+func (u *Usecase) authorizePullRequestAuthor(ctx context.Context, prID string) error {
+	if _, ok, err := u.caller(ctx); !ok || err != nil {
+		return err
+	}
+
+	pr, _, err := u.repo.GetPullRequest(ctx, prID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "resource not found",
+			}
+		}
+		u.log.Error("failed to get pull request", zap.Error(err))
+		return err
+	}
+	return u.authorizeUser(ctx, pr.AuthorID, true)
+}
+
+// authorizeUnavailability applies authorizeUser to the owner of an absence
+// period.
+func (u *Usecase) authorizeUnavailability(ctx context.Context, id int64) error {
+	if _, ok, err := u.caller(ctx); !ok || err != nil {
+		return err
+	}
+
+	ua, err := u.repo.GetUnavailability(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "resource not found",
+			}
+		}
+		u.log.Error("failed to get unavailability", zap.Error(err))
+		return err
+	}
+	return u.authorizeUser(ctx, ua.UserID, true)
+}
+
+// canManage reports whether p may act on userID, a member of teamName. Team
+// leads manage their own team; developers act only for themselves.
+func canManage(p entities.Principal, userID, teamName string, self bool) bool {
+	isSelf := self && userID != "" && userID == p.UserID
+	switch p.Role {
+	case entities.RoleAdmin:
+		return true
+	case entities.RoleTeamLead:
+		return isSelf || (teamName != "" && teamName == p.TeamName)
+	case entities.RoleDeveloper:
+		return isSelf
+	default:
+		return false
+	}
+}
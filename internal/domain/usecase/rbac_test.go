@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"pr-service/config"
+	"pr-service/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+// usersRepo serves GetUserByID from a map; other methods are not used by the
+// authorization helpers and panic through the nil embedded interface.
+type usersRepo struct {
+	repository
+	teams map[string]string
+}
+
+func (r usersRepo) GetUserByID(_ context.Context, userID string) (entities.User, error) {
+	team, ok := r.teams[userID]
+	if !ok {
+		return entities.User{}, pgx.ErrNoRows
+	}
+	return entities.User{UserID: userID, TeamName: team}, nil
+}
+
+func newRBACUsecase(enabled bool) *Usecase {
+	return &Usecase{
+		cfg: &config.ConfigModel{Auth: config.AuthConfig{RBACEnabled: enabled}},
+		log: zap.NewNop(),
+		repo: usersRepo{teams: map[string]string{
+			"lead": "backend", "dev": "backend", "other": "frontend",
+		}},
+	}
+}
+
+func errorCode(err error) entities.ErrorCode {
+	var derr *entities.DomainError
+	if errors.As(err, &derr) {
+		return derr.Code
+	}
+	return ""
+}
+
+func TestAuthorizeDisabled(t *testing.T) {
+	u := newRBACUsecase(false)
+	if err := u.authorizeAdmin(context.Background()); err != nil {
+		t.Fatalf("checks must pass with RBAC disabled, got %v", err)
+	}
+}
+
+func TestAuthorizeRequiresPrincipal(t *testing.T) {
+	u := newRBACUsecase(true)
+	if code := errorCode(u.authorizeRead(context.Background())); code != entities.ErrorCodeForbidden {
+		t.Fatalf("anonymous caller: got %q, want FORBIDDEN", code)
+	}
+	if err := u.authorizeAdmin(entities.WithPrincipal(context.Background(), entities.SystemPrincipal)); err != nil {
+		t.Fatalf("system principal must be admin, got %v", err)
+	}
+}
+
+func TestAuthorizeRoles(t *testing.T) {
+	u := newRBACUsecase(true)
+	lead := entities.Principal{UserID: "lead", TeamName: "backend", Role: entities.RoleTeamLead}
+	dev := entities.Principal{UserID: "dev", TeamName: "backend", Role: entities.RoleDeveloper}
+	reader := entities.Principal{UserID: "reader", TeamName: "backend", Role: entities.RoleReadOnly}
+
+	cases := []struct {
+		name  string
+		p     entities.Principal
+		check func(ctx context.Context) error
+		want  entities.ErrorCode
+	}{
+		{"reader reads", reader, u.authorizeRead, ""},
+		{"reader cannot act for self", reader, func(ctx context.Context) error { return u.authorizeSelf(ctx, "reader") }, entities.ErrorCodeForbidden},
+		{"developer acts for self", dev, func(ctx context.Context) error { return u.authorizeUser(ctx, "dev", true) }, ""},
+		{"developer cannot act for teammate", dev, func(ctx context.Context) error { return u.authorizeUser(ctx, "lead", true) }, entities.ErrorCodeForbidden},
+		{"developer cannot deactivate self", dev, func(ctx context.Context) error { return u.authorizeUser(ctx, "dev", false) }, entities.ErrorCodeForbidden},
+		{"developer cannot manage team", dev, func(ctx context.Context) error { return u.authorizeTeam(ctx, "backend") }, entities.ErrorCodeForbidden},
+		{"lead manages own team", lead, func(ctx context.Context) error { return u.authorizeTeam(ctx, "backend") }, ""},
+		{"lead cannot manage other team", lead, func(ctx context.Context) error { return u.authorizeTeam(ctx, "frontend") }, entities.ErrorCodeForbidden},
+		{"lead manages member", lead, func(ctx context.Context) error { return u.authorizeUser(ctx, "dev", false) }, ""},
+		{"lead cannot manage outsider", lead, func(ctx context.Context) error { return u.authorizeUser(ctx, "other", false) }, entities.ErrorCodeForbidden},
+		{"lead cannot review for member", lead, func(ctx context.Context) error { return u.authorizeSelf(ctx, "dev") }, entities.ErrorCodeForbidden},
+		{"lead on unknown user", lead, func(ctx context.Context) error { return u.authorizeUser(ctx, "ghost", false) }, entities.ErrorCodeNotFound},
+		{"lead is not admin", lead, u.authorizeAdmin, entities.ErrorCodeForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.check(entities.WithPrincipal(context.Background(), tc.p))
+			if code := errorCode(err); code != tc.want {
+				t.Fatalf("got %q (%v), want %q", code, err, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pr-service/internal/domain/entities"
+	"pr-service/internal/domain/metrics"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const staleReviewBatchSize = 100
+
+func (u *Usecase) ListStaleReviews(ctx context.Context) ([]entities.StaleReview, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.ListStaleReviews")
+	defer span.End()
+
+	if err := u.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	reviews, err := u.repo.ListStaleReviews(ctx, staleReviewBatchSize)
+	if err != nil {
+		u.log.Error("failed to list stale reviews", zap.Error(err))
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func (u *Usecase) MarkReviewReminded(ctx context.Context, prID, reviewerID string) error {
+	ctx, span := tracer.Start(ctx, "Usecase.MarkReviewReminded")
+	defer span.End()
+
+	if err := u.authorizeAdmin(ctx); err != nil {
+		return err
+	}
+
+	if err := u.repo.MarkReviewReminded(ctx, prID, reviewerID); err != nil {
+		u.log.Error("failed to mark review reminded", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// EscalateReview takes the review away from a reviewer who exceeded the
+// team's escalation threshold, going through the same path as a manual
+// reassign. If nobody can take it over, the attempt is recorded so it is
+// retried only after another threshold period.
+func (u *Usecase) EscalateReview(ctx context.Context, sr entities.StaleReview) (string, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.EscalateReview")
+	defer span.End()
+
+	if err := u.authorizeAdmin(ctx); err != nil {
+		return "", err
+	}
+
+	waiting := time.Since(sr.AssignedAt).Truncate(time.Minute)
+	reason := fmt.Sprintf("review SLA exceeded: pending for %s (limit %dm)", waiting, sr.EscalateAfter)
+
+	log := u.log.With(
+		zap.String("pull_request_id", sr.PullRequestID),
+		zap.String("reviewer_id", sr.ReviewerID),
+		zap.String("reason", reason),
+	)
+
+	_, newReviewer, err := u.reassignReviewer(ctx, sr.PullRequestID, sr.ReviewerID, reason)
+	if err != nil {
+		var derr *entities.DomainError
+		if errors.As(err, &derr) {
+			log.Warn("failed to escalate stale review", zap.String("code", string(derr.Code)))
+		} else {
+			log.Error("failed to escalate stale review", zap.Error(err))
+		}
+		if markErr := u.repo.MarkEscalationAttempted(ctx, sr.PullRequestID, sr.ReviewerID); markErr != nil {
+			log.Error("failed to record escalation attempt", zap.Error(markErr))
+		}
+		return "", err
+	}
+
+	u.metrics.ReviewersReassigned(metrics.ReassignSourceSLA, 1)
+	log.Info("escalated stale review", zap.String("new_reviewer_id", newReviewer))
+	return newReviewer, nil
+}
@@ -2,13 +2,36 @@ package usecase
 
 import (
 	"context"
+	"math"
 	"pr-service/internal/domain/entities"
+	"sort"
+	"time"
 
 	"go.uber.org/zap"
 )
 
-func (u *Usecase) GetAssignmentsStats(ctx context.Context) (entities.AssignmentsStatsResponse, error) {
-	stats, err := u.repo.GetAssignmentsStats(ctx)
+func (u *Usecase) GetAssignmentsStats(
+	ctx context.Context,
+	filter entities.AssignmentsStatsFilter,
+) (entities.AssignmentsStatsResponse, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.GetAssignmentsStats")
+	defer span.End()
+
+	if err := u.authorizeRead(ctx); err != nil {
+		return entities.AssignmentsStatsResponse{}, err
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return entities.AssignmentsStatsResponse{}, &entities.DomainError{
+			Code:    entities.ErrorCodeInvalidWindow,
+			Message: "from must be before to",
+		}
+	}
+	if err := u.ensureTeamExists(ctx, filter.TeamName); err != nil {
+		return entities.AssignmentsStatsResponse{}, err
+	}
+
+	stats, err := u.repo.GetAssignmentsStats(ctx, filter)
 	if err != nil {
 		u.log.Error("failed to get assignments stats", zap.Error(err))
 		return entities.AssignmentsStatsResponse{}, err
@@ -16,5 +39,237 @@ func (u *Usecase) GetAssignmentsStats(ctx context.Context) (entities.Assignments
 
 	return entities.AssignmentsStatsResponse{
 		Reviewers: stats,
+		Teams:     groupAssignmentsByTeam(stats),
 	}, nil
 }
+
+// groupAssignmentsByTeam expects stats ordered by team name.
+func groupAssignmentsByTeam(stats []entities.ReviewerAssignmentsStat) []entities.TeamAssignmentsStat {
+	teams := make([]entities.TeamAssignmentsStat, 0)
+	for _, s := range stats {
+		if len(teams) == 0 || teams[len(teams)-1].TeamName != s.TeamName {
+			teams = append(teams, entities.TeamAssignmentsStat{
+				TeamName:  s.TeamName,
+				Reviewers: make([]entities.ReviewerAssignmentsStat, 0),
+			})
+		}
+		t := &teams[len(teams)-1]
+		t.Assignments += s.Assignments
+		t.Reviewers = append(t.Reviewers, s)
+	}
+
+	for i := range teams {
+		t := &teams[i]
+		counts := make([]int, 0, len(t.Reviewers))
+		for _, s := range t.Reviewers {
+			if s.IsActive {
+				counts = append(counts, s.Assignments)
+			}
+		}
+		t.ActiveMembers = len(counts)
+		if len(counts) == 0 {
+			continue
+		}
+
+		t.MinAssignments, t.MaxAssignments = counts[0], counts[0]
+		for _, c := range counts[1:] {
+			t.MinAssignments = min(t.MinAssignments, c)
+			t.MaxAssignments = max(t.MaxAssignments, c)
+		}
+		if t.MinAssignments > 0 {
+			ratio := float64(t.MaxAssignments) / float64(t.MinAssignments)
+			t.MaxMinRatio = &ratio
+		}
+		t.Gini = gini(counts)
+	}
+	return teams
+}
+
+// gini returns the Gini coefficient of the counts: 0 when everyone has the
+// same number of assignments, approaching 1 when one person has them all.
+func gini(counts []int) float64 {
+	sorted := append([]int(nil), counts...)
+	sort.Ints(sorted)
+
+	var total, weighted float64
+	for i, c := range sorted {
+		total += float64(c)
+		weighted += float64(i+1) * float64(c)
+	}
+	if total == 0 {
+		return 0
+	}
+	n := float64(len(sorted))
+	return (2*weighted)/(n*total) - (n+1)/n
+}
+
+const defaultSLAStatsWindow = 30 * 24 * time.Hour
+
+// GetSLAStats reports review latency percentiles for PRs created in
+// [from, to) grouped by the author's team and by reviewer, along with the
+// reviews currently breaching their team's SLA. Zero bounds default to the
+// last 30 days.
+func (u *Usecase) GetSLAStats(ctx context.Context, from, to time.Time, teamName string) (entities.SLAStatsResponse, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.GetSLAStats")
+	defer span.End()
+
+	if err := u.authorizeRead(ctx); err != nil {
+		return entities.SLAStatsResponse{}, err
+	}
+
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultSLAStatsWindow)
+	}
+	if !from.Before(to) {
+		return entities.SLAStatsResponse{}, &entities.DomainError{
+			Code:    entities.ErrorCodeInvalidWindow,
+			Message: "from must be before to",
+		}
+	}
+
+	if err := u.ensureTeamExists(ctx, teamName); err != nil {
+		return entities.SLAStatsResponse{}, err
+	}
+
+	timings, err := u.repo.ListReviewTimings(ctx, from, to, teamName)
+	if err != nil {
+		u.log.Error("failed to list review timings", zap.Error(err))
+		return entities.SLAStatsResponse{}, err
+	}
+
+	breaching, err := u.repo.ListSLABreaches(ctx, teamName)
+	if err != nil {
+		u.log.Error("failed to list SLA breaches", zap.Error(err))
+		return entities.SLAStatsResponse{}, err
+	}
+
+	teams, reviewers := aggregateReviewTimings(timings)
+	return entities.SLAStatsResponse{
+		From:      from,
+		To:        to,
+		Teams:     teams,
+		Reviewers: reviewers,
+		Breaching: breaching,
+	}, nil
+}
+
+type reviewSamples struct {
+	firstReview []time.Duration
+	approval    []time.Duration
+	merge       []time.Duration
+}
+
+func (s *reviewSamples) stats() (first, approval, merge entities.DurationPercentiles) {
+	return durationPercentiles(s.firstReview), durationPercentiles(s.approval), durationPercentiles(s.merge)
+}
+
+// aggregateReviewTimings measures first review and approval from the moment
+// each reviewer was assigned, and merge from PR creation. A PR's merge time
+// counts once for its team and once for each of its reviewers.
+func aggregateReviewTimings(timings []entities.ReviewTiming) (teams, reviewers []entities.SLAGroupStats) {
+	byTeam := make(map[string]*reviewSamples)
+	byReviewer := make(map[string]*reviewSamples)
+	mergeCounted := make(map[string]struct{})
+
+	samplesFor := func(m map[string]*reviewSamples, key string) *reviewSamples {
+		s, ok := m[key]
+		if !ok {
+			s = &reviewSamples{}
+			m[key] = s
+		}
+		return s
+	}
+
+	for _, rt := range timings {
+		team := samplesFor(byTeam, rt.TeamName)
+		if rt.MergedAt != nil {
+			if _, ok := mergeCounted[rt.PullRequestID]; !ok {
+				mergeCounted[rt.PullRequestID] = struct{}{}
+				team.merge = append(team.merge, rt.MergedAt.Sub(rt.CreatedAt))
+			}
+		}
+
+		if rt.ReviewerID == "" || rt.AssignedAt == nil {
+			continue
+		}
+		reviewer := samplesFor(byReviewer, rt.ReviewerID)
+		if rt.FirstResponseAt != nil {
+			d := rt.FirstResponseAt.Sub(*rt.AssignedAt)
+			team.firstReview = append(team.firstReview, d)
+			reviewer.firstReview = append(reviewer.firstReview, d)
+		}
+		if rt.ApprovedAt != nil {
+			d := rt.ApprovedAt.Sub(*rt.AssignedAt)
+			team.approval = append(team.approval, d)
+			reviewer.approval = append(reviewer.approval, d)
+		}
+		if rt.MergedAt != nil {
+			reviewer.merge = append(reviewer.merge, rt.MergedAt.Sub(rt.CreatedAt))
+		}
+	}
+
+	teams = make([]entities.SLAGroupStats, 0, len(byTeam))
+	for name, s := range byTeam {
+		g := entities.SLAGroupStats{TeamName: name}
+		g.TimeToFirstReview, g.TimeToApproval, g.TimeToMerge = s.stats()
+		teams = append(teams, g)
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].TeamName < teams[j].TeamName })
+
+	reviewers = make([]entities.SLAGroupStats, 0, len(byReviewer))
+	for id, s := range byReviewer {
+		g := entities.SLAGroupStats{UserID: id}
+		g.TimeToFirstReview, g.TimeToApproval, g.TimeToMerge = s.stats()
+		reviewers = append(reviewers, g)
+	}
+	sort.Slice(reviewers, func(i, j int) bool { return reviewers[i].UserID < reviewers[j].UserID })
+
+	return teams, reviewers
+}
+
+// durationPercentiles uses the nearest-rank method, so every reported value
+// is an actual observation.
+func durationPercentiles(samples []time.Duration) entities.DurationPercentiles {
+	if len(samples) == 0 {
+		return entities.DurationPercentiles{}
+	}
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := func(p float64) int64 {
+		idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if idx < 0 {
+			idx = 0
+		}
+		return int64(sorted[idx].Seconds())
+	}
+
+	return entities.DurationPercentiles{
+		Count:      len(sorted),
+		P50Seconds: rank(0.50),
+		P90Seconds: rank(0.90),
+		P99Seconds: rank(0.99),
+	}
+}
+
+// ensureTeamExists checks an optional team filter; an empty name passes.
+func (u *Usecase) ensureTeamExists(ctx context.Context, teamName string) error {
+	if teamName == "" {
+		return nil
+	}
+	exists, err := u.repo.TeamExists(ctx, teamName)
+	if err != nil {
+		u.log.Error("failed to check team exists", zap.Error(err))
+		return err
+	}
+	if !exists {
+		return &entities.DomainError{
+			Code:    entities.ErrorCodeNotFound,
+			Message: "resource not found",
+		}
+	}
+	return nil
+}
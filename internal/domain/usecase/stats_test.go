@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"pr-service/internal/domain/entities"
+)
+
+func TestDurationPercentiles(t *testing.T) {
+	samples := make([]time.Duration, 0, 100)
+	for i := 100; i >= 1; i-- {
+		samples = append(samples, time.Duration(i)*time.Minute)
+	}
+
+	got := durationPercentiles(samples)
+	want := entities.DurationPercentiles{Count: 100, P50Seconds: 50 * 60, P90Seconds: 90 * 60, P99Seconds: 99 * 60}
+	if got != want {
+		t.Fatalf("durationPercentiles = %+v, want %+v", got, want)
+	}
+
+	if got := durationPercentiles(nil); got != (entities.DurationPercentiles{}) {
+		t.Fatalf("expected zero percentiles for no samples, got %+v", got)
+	}
+	if got := durationPercentiles([]time.Duration{time.Hour}); got.P50Seconds != 3600 || got.P99Seconds != 3600 {
+		t.Fatalf("single sample must be every percentile, got %+v", got)
+	}
+}
+
+func TestAggregateReviewTimings(t *testing.T) {
+	created := time.Date(2025, time.June, 2, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		v := created.Add(d)
+		return &v
+	}
+
+	timings := []entities.ReviewTiming{
+		{PullRequestID: "pr-1", TeamName: "backend", ReviewerID: "u1", CreatedAt: created, MergedAt: at(5 * time.Hour),
+			AssignedAt: at(0), FirstResponseAt: at(time.Hour), ApprovedAt: at(2 * time.Hour)},
+		{PullRequestID: "pr-1", TeamName: "backend", ReviewerID: "u2", CreatedAt: created, MergedAt: at(5 * time.Hour),
+			AssignedAt: at(time.Hour), FirstResponseAt: at(4 * time.Hour)},
+		{PullRequestID: "pr-2", TeamName: "backend", ReviewerID: "u1", CreatedAt: created, AssignedAt: at(0)},
+		{PullRequestID: "pr-3", TeamName: "frontend", CreatedAt: created, MergedAt: at(time.Hour)},
+	}
+
+	teams, reviewers := aggregateReviewTimings(timings)
+
+	if len(teams) != 2 || teams[0].TeamName != "backend" || teams[1].TeamName != "frontend" {
+		t.Fatalf("unexpected teams %+v", teams)
+	}
+	backend := teams[0]
+	if backend.TimeToFirstReview.Count != 2 || backend.TimeToFirstReview.P50Seconds != 3600 || backend.TimeToFirstReview.P90Seconds != 3*3600 {
+		t.Fatalf("unexpected backend first review %+v", backend.TimeToFirstReview)
+	}
+	if backend.TimeToApproval.Count != 1 || backend.TimeToApproval.P50Seconds != 2*3600 {
+		t.Fatalf("unexpected backend approval %+v", backend.TimeToApproval)
+	}
+	if backend.TimeToMerge.Count != 1 || backend.TimeToMerge.P50Seconds != 5*3600 {
+		t.Fatalf("merge must be counted once per PR, got %+v", backend.TimeToMerge)
+	}
+	if teams[1].TimeToMerge.Count != 1 || teams[1].TimeToFirstReview.Count != 0 {
+		t.Fatalf("unexpected frontend stats %+v", teams[1])
+	}
+
+	if len(reviewers) != 2 || reviewers[0].UserID != "u1" || reviewers[1].UserID != "u2" {
+		t.Fatalf("unexpected reviewers %+v", reviewers)
+	}
+	if reviewers[0].TimeToFirstReview.Count != 1 || reviewers[0].TimeToMerge.Count != 1 {
+		t.Fatalf("unexpected u1 stats %+v", reviewers[0])
+	}
+	if reviewers[1].TimeToFirstReview.P50Seconds != 3*3600 || reviewers[1].TimeToApproval.Count != 0 {
+		t.Fatalf("unexpected u2 stats %+v", reviewers[1])
+	}
+}
+
+func TestGini(t *testing.T) {
+	cases := []struct {
+		name   string
+		counts []int
+		want   float64
+	}{
+		{"equal", []int{3, 3, 3}, 0},
+		{"all zero", []int{0, 0}, 0},
+		{"one has all", []int{0, 0, 0, 4}, 0.75},
+		{"skewed", []int{1, 3}, 0.25},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := gini(tc.counts); math.Abs(got-tc.want) > 1e-9 {
+				t.Fatalf("gini(%v) = %v, want %v", tc.counts, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGroupAssignmentsByTeam(t *testing.T) {
+	stats := []entities.ReviewerAssignmentsStat{
+		{UserID: "a1", TeamName: "backend", IsActive: true, Assignments: 4},
+		{UserID: "a2", TeamName: "backend", IsActive: true, Assignments: 2},
+		{UserID: "a3", TeamName: "backend", IsActive: false, Assignments: 7},
+		{UserID: "b1", TeamName: "frontend", IsActive: true, Assignments: 3},
+		{UserID: "b2", TeamName: "frontend", IsActive: true, Assignments: 0},
+	}
+
+	teams := groupAssignmentsByTeam(stats)
+	if len(teams) != 2 {
+		t.Fatalf("expected 2 teams, got %+v", teams)
+	}
+
+	backend := teams[0]
+	if backend.Assignments != 13 || backend.ActiveMembers != 2 || len(backend.Reviewers) != 3 {
+		t.Fatalf("unexpected backend totals %+v", backend)
+	}
+	if backend.MinAssignments != 2 || backend.MaxAssignments != 4 || backend.MaxMinRatio == nil || *backend.MaxMinRatio != 2 {
+		t.Fatalf("inactive members must not affect fairness, got %+v", backend)
+	}
+
+	frontend := teams[1]
+	if frontend.MaxMinRatio != nil || frontend.MinAssignments != 0 || frontend.Gini != 0.5 {
+		t.Fatalf("unexpected frontend fairness %+v", frontend)
+	}
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"pr-service/internal/domain/entities"
+	"sort"
+)
+
+type AssignmentStrategy interface {
+	Pick(ctx context.Context, teamName string, candidates []entities.User, limit int) ([]string, error)
+}
+
+type loadSource interface {
+	GetOpenReviewLoad(ctx context.Context, userIDs []string) (map[string]int, error)
+}
+
+type rotationStore interface {
+	GetRotationCursor(ctx context.Context, teamName string) (string, error)
+}
+
+func newStrategies(repo repository) map[string]AssignmentStrategy {
+	return map[string]AssignmentStrategy{
+		entities.AssignmentStrategyRandom:      randomStrategy{},
+		entities.AssignmentStrategyRoundRobin:  roundRobinStrategy{repo: repo},
+		entities.AssignmentStrategyLeastLoaded: leastLoadedStrategy{repo: repo},
+		entities.AssignmentStrategyWeighted:    weightedStrategy{repo: repo},
+	}
+}
+
+func (u *Usecase) strategy(name string) AssignmentStrategy {
+	if s, ok := u.strategies[name]; ok {
+		return s
+	}
+	return u.strategies[entities.DefaultAssignmentStrategy]
+}
+
+type randomStrategy struct{}
+
+func (randomStrategy) Pick(_ context.Context, _ string, candidates []entities.User, limit int) ([]string, error) {
+	return pickReviewers(candidates, nil, limit), nil
+}
+
+type leastLoadedStrategy struct {
+	repo loadSource
+}
+
+func (s leastLoadedStrategy) Pick(ctx context.Context, _ string, candidates []entities.User, limit int) ([]string, error) {
+	if limit <= 0 || len(candidates) == 0 {
+		return nil, nil
+	}
+	load, err := s.repo.GetOpenReviewLoad(ctx, userIDs(candidates))
+	if err != nil {
+		return nil, err
+	}
+	return pickReviewers(candidates, load, limit), nil
+}
+
+type weightedStrategy struct {
+	repo loadSource
+}
+
+// Pick draws candidates without replacement with probability proportional
+// to 1/(1+open reviews), so loaded reviewers are still eligible but rarer.
+func (s weightedStrategy) Pick(ctx context.Context, _ string, candidates []entities.User, limit int) ([]string, error) {
+	if limit <= 0 || len(candidates) == 0 {
+		return nil, nil
+	}
+	load, err := s.repo.GetOpenReviewLoad(ctx, userIDs(candidates))
+	if err != nil {
+		return nil, err
+	}
+
+	type keyed struct {
+		id  string
+		key float64
+	}
+	keys := make([]keyed, 0, len(candidates))
+	for _, c := range candidates {
+		weight := 1 / float64(1+load[c.UserID])
+		keys = append(keys, keyed{id: c.UserID, key: math.Pow(rand.Float64(), 1/weight)})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].key > keys[j].key })
+
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+	res := make([]string, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, k.id)
+	}
+	return res, nil
+}
+
+type roundRobinStrategy struct {
+	repo rotationStore
+	rot  *rotations
+}
+
+// Pick reads the cursor without a lock, so its result is provisional: the
+// pick is recorded in rot and repeated from the locked cursor when the
+// assignment is stored; see rotations.
+func (s roundRobinStrategy) Pick(ctx context.Context, teamName string, candidates []entities.User, limit int) ([]string, error) {
+	if limit <= 0 || len(candidates) == 0 {
+		return nil, nil
+	}
+
+	ids := userIDs(candidates)
+	sort.Strings(ids)
+
+	cursor, err := s.repo.GetRotationCursor(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	s.rot.add(teamName, ids, limit)
+	return rotate(ids, cursor, limit), nil
+}
+
+type rotationPick struct {
+	teamName  string
+	sortedIDs []string
+	limit     int
+}
+
+// rotations collects the round_robin picks of one assignment, which may come
+// from several picks per team (code owners, the rest of the team, working
+// hours) and from fallback teams. How many reviewers each pick yields does
+// not depend on the cursor, so the picks can be repeated from the cursors
+// locked by the transaction that stores the assignment.
+type rotations struct {
+	picks []rotationPick
+}
+
+func (r *rotations) add(teamName string, sortedIDs []string, limit int) {
+	if r != nil {
+		r.picks = append(r.picks, rotationPick{teamName: teamName, sortedIDs: sortedIDs, limit: limit})
+	}
+}
+
+// rotation returns nil when the assignment made no round_robin picks. With
+// round_robin every pick of an assignment is recorded, so the repeated picks
+// replace all of its reviewers; each team's cursor moves once, to the pick
+// furthest along its rotation.
+func (r *rotations) rotation() *entities.Rotation {
+	if r == nil || len(r.picks) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	teams := make([]string, 0)
+	for _, p := range r.picks {
+		if !seen[p.teamName] {
+			seen[p.teamName] = true
+			teams = append(teams, p.teamName)
+		}
+	}
+	sort.Strings(teams)
+
+	picks := r.picks
+	return &entities.Rotation{
+		Teams: teams,
+		Pick: func(cursors map[string]string) ([]string, map[string]string) {
+			var reviewers []string
+			byTeam := make(map[string][]string, len(teams))
+			for _, p := range picks {
+				picked := rotate(p.sortedIDs, cursors[p.teamName], p.limit)
+				reviewers = append(reviewers, picked...)
+				byTeam[p.teamName] = append(byTeam[p.teamName], picked...)
+			}
+
+			next := make(map[string]string, len(byTeam))
+			for team, picked := range byTeam {
+				next[team] = lastInRotation(cursors[team], picked)
+			}
+			return reviewers, next
+		},
+	}
+}
+
+// lastInRotation returns the id that comes last when walking the sorted
+// rotation from cursor: ids after the cursor come first, then the wrapped
+// ones up to and including the cursor.
+func lastInRotation(cursor string, picked []string) string {
+	last, wrapped := cursor, false
+	for i, id := range picked {
+		w := id <= cursor
+		switch {
+		case i == 0, w && !wrapped:
+			last, wrapped = id, w
+		case w == wrapped && id > last:
+			last = id
+		}
+	}
+	return last
+}
+
+// rotate returns up to limit ids from the sorted slice, starting right after
+// cursor and wrapping around.
+func rotate(sortedIDs []string, cursor string, limit int) []string {
+	start := sort.SearchStrings(sortedIDs, cursor)
+	if start < len(sortedIDs) && sortedIDs[start] == cursor {
+		start++
+	}
+
+	if limit > len(sortedIDs) {
+		limit = len(sortedIDs)
+	}
+	res := make([]string, 0, limit)
+	for i := 0; i < limit; i++ {
+		res = append(res, sortedIDs[(start+i)%len(sortedIDs)])
+	}
+	return res
+}
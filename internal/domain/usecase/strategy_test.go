@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"pr-service/internal/domain/entities"
+)
+
+type cursorRepo struct {
+	repository
+	cursor string
+}
+
+func (r cursorRepo) GetRotationCursor(context.Context, string) (string, error) {
+	return r.cursor, nil
+}
+
+func TestLastInRotation(t *testing.T) {
+	cases := []struct {
+		cursor string
+		picked []string
+		want   string
+	}{
+		{"", []string{"b", "a"}, "b"},
+		{"b", []string{"c", "d"}, "d"},
+		{"c", []string{"d", "a"}, "a"},
+		{"c", []string{"a", "d", "b"}, "b"},
+		{"c", []string{"c", "a"}, "c"},
+	}
+	for _, tc := range cases {
+		if got := lastInRotation(tc.cursor, tc.picked); got != tc.want {
+			t.Errorf("lastInRotation(%q, %v) = %q, want %q", tc.cursor, tc.picked, got, tc.want)
+		}
+	}
+}
+
+// Picks from several buckets of one assignment move the cursor once, past
+// everything assigned, instead of once per bucket.
+func TestRoundRobinAdvancesOncePerAssignment(t *testing.T) {
+	u := &Usecase{strategies: newStrategies(cursorRepo{cursor: "a"})}
+	settings := entities.TeamSettings{AssignmentStrategy: entities.AssignmentStrategyRoundRobin}
+	users := func(ids ...string) []entities.User {
+		res := make([]entities.User, 0, len(ids))
+		for _, id := range ids {
+			res = append(res, entities.User{UserID: id})
+		}
+		return res
+	}
+
+	rot := &rotations{}
+	owners, err := u.pick(context.Background(), settings, "backend", users("a", "d"), 1, rot)
+	if err != nil {
+		t.Fatalf("pick(owners): %v", err)
+	}
+	others, err := u.pick(context.Background(), settings, "backend", users("b", "c"), 1, rot)
+	if err != nil {
+		t.Fatalf("pick(others): %v", err)
+	}
+	if owners[0] != "d" || others[0] != "b" {
+		t.Fatalf("picked %v and %v, want [d] and [b]", owners, others)
+	}
+
+	rotation := rot.rotation()
+	if rotation == nil || !reflect.DeepEqual(rotation.Teams, []string{"backend"}) {
+		t.Fatalf("rotation = %+v", rotation)
+	}
+	reviewers, next := rotation.Pick(map[string]string{"backend": "a"})
+	if !reflect.DeepEqual(reviewers, []string{"d", "b"}) || next["backend"] != "d" {
+		t.Fatalf("Pick(a) = %v, %v; want [d b] and cursor d", reviewers, next)
+	}
+}
+
+// A concurrent assignment may move the cursor after the provisional pick;
+// the picks are then repeated from the cursor locked when storing.
+func TestRoundRobinRepicksFromLockedCursor(t *testing.T) {
+	u := &Usecase{strategies: newStrategies(cursorRepo{cursor: "a"})}
+	settings := entities.TeamSettings{
+		AssignmentStrategy: entities.AssignmentStrategyRoundRobin,
+		FallbackTeams:      []string{"frontend"},
+	}
+
+	rot := &rotations{}
+	for _, p := range []struct {
+		team string
+		ids  []string
+	}{
+		{"backend", []string{"c", "a", "b"}},
+		{"frontend", []string{"y", "x"}},
+	} {
+		candidates := make([]entities.User, 0, len(p.ids))
+		for _, id := range p.ids {
+			candidates = append(candidates, entities.User{UserID: id})
+		}
+		if _, err := u.pick(context.Background(), settings, p.team, candidates, 1, rot); err != nil {
+			t.Fatalf("pick(%s): %v", p.team, err)
+		}
+	}
+
+	rotation := rot.rotation()
+	if rotation == nil || !reflect.DeepEqual(rotation.Teams, []string{"backend", "frontend"}) {
+		t.Fatalf("rotation = %+v", rotation)
+	}
+	reviewers, next := rotation.Pick(map[string]string{"backend": "c", "frontend": "x"})
+	if !reflect.DeepEqual(reviewers, []string{"a", "y"}) {
+		t.Fatalf("reviewers = %v, want [a y]", reviewers)
+	}
+	if next["backend"] != "a" || next["frontend"] != "y" {
+		t.Fatalf("next = %v", next)
+	}
+}
+
+func TestRotationNilWithoutRoundRobin(t *testing.T) {
+	u := &Usecase{strategies: newStrategies(cursorRepo{})}
+	settings := entities.TeamSettings{AssignmentStrategy: entities.AssignmentStrategyRandom}
+
+	rot := &rotations{}
+	if _, err := u.pick(context.Background(), settings, "backend", []entities.User{{UserID: "a"}}, 1, rot); err != nil {
+		t.Fatalf("pick: %v", err)
+	}
+	if rotation := rot.rotation(); rotation != nil {
+		t.Fatalf("rotation = %+v, want nil", rotation)
+	}
+}
@@ -10,6 +10,22 @@ import (
 )
 
 func (u *Usecase) CreateTeam(ctx context.Context, team entities.Team) (entities.Team, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.CreateTeam")
+	defer span.End()
+
+	if err := u.authorizeTeam(ctx, team.TeamName); err != nil {
+		return entities.Team{}, err
+	}
+
+	for i := range team.Members {
+		if err := normalizeWorkingHours(&team.Members[i]); err != nil {
+			return entities.Team{}, &entities.DomainError{
+				Code:    entities.ErrorCodeInvalidSettings,
+				Message: err.Error(),
+			}
+		}
+	}
+
 	exists, err := u.repo.TeamExists(ctx, team.TeamName)
 	if err != nil {
 		u.log.Error("failed to check team exists", zap.Error(err))
@@ -36,6 +52,13 @@ func (u *Usecase) CreateTeam(ctx context.Context, team entities.Team) (entities.
 }
 
 func (u *Usecase) GetTeam(ctx context.Context, teamName string) (entities.Team, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.GetTeam")
+	defer span.End()
+
+	if err := u.authorizeRead(ctx); err != nil {
+		return entities.Team{}, err
+	}
+
 	team, err := u.repo.GetTeam(ctx, teamName)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -49,3 +72,116 @@ func (u *Usecase) GetTeam(ctx context.Context, teamName string) (entities.Team,
 	}
 	return team, nil
 }
+
+func (u *Usecase) GetTeamSettings(ctx context.Context, teamName string) (entities.TeamSettings, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.GetTeamSettings")
+	defer span.End()
+
+	if err := u.authorizeRead(ctx); err != nil {
+		return entities.TeamSettings{}, err
+	}
+
+	settings, err := u.repo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.TeamSettings{}, &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "resource not found",
+			}
+		}
+		u.log.Error("failed to get team settings", zap.Error(err))
+		return entities.TeamSettings{}, err
+	}
+	return settings, nil
+}
+
+func (u *Usecase) UpdateTeamSettings(ctx context.Context, req entities.UpdateTeamSettingsRequest) (entities.TeamSettings, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.UpdateTeamSettings")
+	defer span.End()
+
+	if err := u.authorizeTeam(ctx, req.TeamName); err != nil {
+		return entities.TeamSettings{}, err
+	}
+
+	settings, err := u.GetTeamSettings(ctx, req.TeamName)
+	if err != nil {
+		return entities.TeamSettings{}, err
+	}
+
+	if req.AssignmentStrategy != nil {
+		settings.AssignmentStrategy = *req.AssignmentStrategy
+	}
+	if req.ReviewersCount != nil {
+		settings.ReviewersCount = *req.ReviewersCount
+	}
+	if req.MinApprovals != nil {
+		settings.MinApprovals = *req.MinApprovals
+	}
+	if req.RequireAllApproved != nil {
+		settings.RequireAllApproved = *req.RequireAllApproved
+	}
+	if req.MaxOpenReviews != nil {
+		settings.MaxOpenReviews = *req.MaxOpenReviews
+	}
+	if req.PreferWorkingHours != nil {
+		settings.PreferWorkingHours = *req.PreferWorkingHours
+	}
+	if req.ReminderAfterMinutes != nil {
+		settings.ReminderAfterMinutes = *req.ReminderAfterMinutes
+	}
+	if req.EscalateAfterMinutes != nil {
+		settings.EscalateAfterMinutes = *req.EscalateAfterMinutes
+	}
+	if settings.ReminderAfterMinutes > 0 && settings.EscalateAfterMinutes > 0 &&
+		settings.EscalateAfterMinutes <= settings.ReminderAfterMinutes {
+		return entities.TeamSettings{}, &entities.DomainError{
+			Code:    entities.ErrorCodeInvalidSettings,
+			Message: "escalate_after_minutes must be greater than reminder_after_minutes",
+		}
+	}
+	if req.FallbackTeams != nil {
+		teams, err := u.validateFallbackTeams(ctx, req.TeamName, *req.FallbackTeams)
+		if err != nil {
+			return entities.TeamSettings{}, err
+		}
+		settings.FallbackTeams = teams
+	}
+
+	if err := u.repo.UpsertTeamSettings(ctx, settings); err != nil {
+		u.log.Error("failed to update team settings", zap.Error(err))
+		return entities.TeamSettings{}, err
+	}
+	return settings, nil
+}
+
+func (u *Usecase) validateFallbackTeams(ctx context.Context, teamName string, teams []string) ([]string, error) {
+	res := make([]string, 0, len(teams))
+	seen := make(map[string]bool, len(teams))
+	for _, team := range teams {
+		if seen[team] {
+			continue
+		}
+		seen[team] = true
+
+		if team == teamName {
+			return nil, &entities.DomainError{
+				Code:    entities.ErrorCodeInvalidSettings,
+				Message: "team cannot fall back to itself",
+			}
+		}
+
+		exists, err := u.repo.TeamExists(ctx, team)
+		if err != nil {
+			u.log.Error("failed to check fallback team exists", zap.Error(err))
+			return nil, err
+		}
+		if !exists {
+			return nil, &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "fallback team " + team + " not found",
+			}
+		}
+		res = append(res, team)
+	}
+	return res, nil
+}
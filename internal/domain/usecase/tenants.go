@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"pr-service/internal/domain/entities"
+
+	"github.com/jackc/pgconn"
+	"go.uber.org/zap"
+)
+
+func (u *Usecase) CreateTenant(ctx context.Context, req entities.CreateTenantRequest) (entities.Tenant, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.CreateTenant")
+	defer span.End()
+
+	if err := u.authorizeAdmin(ctx); err != nil {
+		return entities.Tenant{}, err
+	}
+
+	tenant, err := u.repo.CreateTenant(ctx, entities.Tenant{TenantID: req.TenantID, Name: req.Name})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return entities.Tenant{}, &entities.DomainError{
+				Code:    entities.ErrorCodeTenantExists,
+				Message: "tenant_id already exists",
+			}
+		}
+		u.log.Error("failed to create tenant", zap.Error(err))
+		return entities.Tenant{}, err
+	}
+
+	u.log.Info("tenant created", zap.String("tenant_id", tenant.TenantID))
+	return tenant, nil
+}
+
+func (u *Usecase) ListTenants(ctx context.Context) ([]entities.Tenant, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.ListTenants")
+	defer span.End()
+
+	if err := u.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	tenants, err := u.repo.ListTenants(ctx)
+	if err != nil {
+		u.log.Error("failed to list tenants", zap.Error(err))
+		return nil, err
+	}
+	return tenants, nil
+}
+
+// CheckTenant rejects tenants named by a request that do not exist. Like
+// Authenticate it is part of identification and checks no role.
+func (u *Usecase) CheckTenant(ctx context.Context, tenantID string) error {
+	ctx, span := tracer.Start(ctx, "Usecase.CheckTenant")
+	defer span.End()
+
+	if tenantID == entities.DefaultTenant {
+		return nil
+	}
+
+	exists, err := u.repo.TenantExists(ctx, tenantID)
+	if err != nil {
+		u.log.Error("failed to check tenant exists", zap.Error(err))
+		return err
+	}
+	if !exists {
+		return &entities.DomainError{
+			Code:    entities.ErrorCodeUnauthorized,
+			Message: "unknown tenant",
+		}
+	}
+	return nil
+}
+
+// RotateWebhookSecret generates a new secret for the tenant's inbound webhooks
+// of a provider; the previous one stops working at once. Secrets are always
+// generated so that no two tenants can share one.
+func (u *Usecase) RotateWebhookSecret(ctx context.Context, req entities.RotateWebhookSecretRequest) (entities.TenantWebhookSecret, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.RotateWebhookSecret")
+	defer span.End()
+
+	if err := u.authorizeAdmin(ctx); err != nil {
+		return entities.TenantWebhookSecret{}, err
+	}
+
+	exists, err := u.repo.TenantExists(ctx, req.TenantID)
+	if err != nil {
+		u.log.Error("failed to check tenant exists", zap.Error(err))
+		return entities.TenantWebhookSecret{}, err
+	}
+	if !exists {
+		return entities.TenantWebhookSecret{}, &entities.DomainError{
+			Code:    entities.ErrorCodeNotFound,
+			Message: "resource not found",
+		}
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		u.log.Error("failed to generate webhook secret", zap.Error(err))
+		return entities.TenantWebhookSecret{}, err
+	}
+	secret := entities.TenantWebhookSecret{
+		TenantID: req.TenantID,
+		Provider: req.Provider,
+		Secret:   hex.EncodeToString(buf),
+	}
+	if err := u.repo.SetWebhookSecret(ctx, secret); err != nil {
+		u.log.Error("failed to set webhook secret", zap.Error(err))
+		return entities.TenantWebhookSecret{}, err
+	}
+
+	u.log.Info("webhook secret rotated",
+		zap.String("tenant_id", secret.TenantID),
+		zap.String("provider", secret.Provider),
+	)
+	return secret, nil
+}
+
+// WebhookSecrets lists the secrets of every tenant an inbound webhook of the
+// provider may be signed with. Like CheckTenant it is part of identification
+// and checks no role.
+func (u *Usecase) WebhookSecrets(ctx context.Context, provider string) ([]entities.TenantWebhookSecret, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.WebhookSecrets")
+	defer span.End()
+
+	secrets, err := u.repo.ListWebhookSecrets(ctx, provider)
+	if err != nil {
+		u.log.Error("failed to list webhook secrets", zap.Error(err))
+		return nil, err
+	}
+	return secrets, nil
+}
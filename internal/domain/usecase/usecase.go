@@ -4,8 +4,12 @@ import (
 	"context"
 	"math/rand"
 	"pr-service/config"
+	"pr-service/internal/domain/auth"
 	"pr-service/internal/domain/entities"
+	"pr-service/internal/domain/metrics"
 	"pr-service/internal/domain/repository/postgres"
+	"sort"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -14,49 +18,122 @@ type repository interface {
 	TeamExists(ctx context.Context, teamName string) (bool, error)
 	CreateTeam(ctx context.Context, team entities.Team) error
 	GetTeam(ctx context.Context, teamName string) (entities.Team, error)
+	GetTeamSettings(ctx context.Context, teamName string) (entities.TeamSettings, error)
+	UpsertTeamCodeowners(ctx context.Context, teamName, content string) (entities.TeamCodeowners, error)
+	GetTeamCodeowners(ctx context.Context, teamName string) (entities.TeamCodeowners, error)
+	UpsertTeamSettings(ctx context.Context, settings entities.TeamSettings) error
 
 	SetUserIsActive(ctx context.Context, userID string, isActive bool) (entities.User, error)
 	GetUserByID(ctx context.Context, userID string) (entities.User, error)
+	GetUserRole(ctx context.Context, userID string) (entities.UserRole, error)
+	SetUserRole(ctx context.Context, userID, role string) (entities.UserRole, error)
+	LinkIdentity(ctx context.Context, identity entities.UserIdentity) error
+	GetUserIDByIdentity(ctx context.Context, provider, login string) (string, error)
+	SetUserMaxOpenReviews(ctx context.Context, userID string, limit *int) error
+	GetReviewCapacity(ctx context.Context, userID string) (entities.ReviewCapacity, error)
 	ListTeamActiveUsersExcept(ctx context.Context, teamName, exceptUserID string) ([]entities.User, error)
+	GetOpenReviewLoad(ctx context.Context, userIDs []string) (map[string]int, error)
+	GetRotationCursor(ctx context.Context, teamName string) (string, error)
 
-	CreatePullRequest(ctx context.Context, pr entities.PullRequest, reviewers []string) error
+	CreatePullRequest(
+		ctx context.Context,
+		pr entities.PullRequest,
+		reviewers []string,
+		rotation *entities.Rotation,
+	) error
 	GetPullRequest(ctx context.Context, prID string) (entities.PullRequest, []string, error)
-	MarkPullRequestMerged(ctx context.Context, prID string) (entities.PullRequest, []string, error)
-	ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID string) error
-	ListPullRequestsByReviewer(ctx context.Context, reviewerID string) ([]entities.PullRequestShort, error)
+	MarkPullRequestMerged(
+		ctx context.Context,
+		prID string,
+		check func(reviews []entities.ReviewerState) error,
+	) (entities.PullRequest, []string, error)
+	ClosePullRequest(ctx context.Context, prID string) (entities.PullRequest, []string, error)
+	OpenPullRequest(
+		ctx context.Context,
+		prID, fromStatus string,
+		reviewers []string,
+		rotation *entities.Rotation,
+	) error
+	ReplaceReviewer(ctx context.Context, prID, oldUserID, newUserID, reason string, rotation *entities.Rotation) (string, error)
+	SetReviewState(ctx context.Context, prID, reviewerID, state string) error
+	ListPullRequestsByReviewer(ctx context.Context, reviewerID string, onlyPending bool) ([]entities.PullRequestShort, error)
+	ListAssignmentEvents(ctx context.Context, prID string) ([]entities.AssignmentEvent, error)
 
-	GetAssignmentsStats(ctx context.Context) ([]entities.ReviewerAssignmentsStat, error)
+	GetAssignmentsStats(ctx context.Context, filter entities.AssignmentsStatsFilter) ([]entities.ReviewerAssignmentsStat, error)
+	ListReviewTimings(ctx context.Context, from, to time.Time, teamName string) ([]entities.ReviewTiming, error)
+	ListSLABreaches(ctx context.Context, teamName string) ([]entities.SLABreach, error)
 
-	BulkDeactivateTeamUsers(ctx context.Context, teamName string, userIDs []string) (entities.BulkDeactivateResult, error)
+	BulkDeactivateTeamUsers(
+		ctx context.Context,
+		teamName string,
+		userIDs []string,
+		fallbackTeams []string,
+	) (entities.BulkDeactivateResult, error)
+
+	CreateUnavailability(ctx context.Context, ua entities.Unavailability) (entities.Unavailability, error)
+	GetUnavailability(ctx context.Context, id int64) (entities.Unavailability, error)
+	UpdateUnavailability(ctx context.Context, ua entities.Unavailability) (entities.Unavailability, error)
+	DeleteUnavailability(ctx context.Context, id int64) error
+	ListUnavailability(ctx context.Context, userID string) ([]entities.Unavailability, error)
+	ListStartedUnavailability(ctx context.Context, limit int) ([]entities.Unavailability, error)
+	ReassignForUnavailability(
+		ctx context.Context,
+		ua entities.Unavailability,
+		teamName string,
+		fallbackTeams []string,
+	) (int, error)
+
+	ListStaleReviews(ctx context.Context, limit int) ([]entities.StaleReview, error)
+	MarkReviewReminded(ctx context.Context, prID, reviewerID string) error
+	MarkEscalationAttempted(ctx context.Context, prID, reviewerID string) error
+
+	CreateAPIKey(ctx context.Context, name, prefix, hash string, userID *string) (entities.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]entities.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int64) error
+	UseAPIKey(ctx context.Context, hash string) (entities.APIKey, error)
+
+	CreateTenant(ctx context.Context, t entities.Tenant) (entities.Tenant, error)
+	ListTenants(ctx context.Context) ([]entities.Tenant, error)
+	TenantExists(ctx context.Context, tenantID string) (bool, error)
+	SetWebhookSecret(ctx context.Context, secret entities.TenantWebhookSecret) error
+	ListWebhookSecrets(ctx context.Context, provider string) ([]entities.TenantWebhookSecret, error)
+
+	CreateWebhook(ctx context.Context, wh entities.Webhook) (entities.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]entities.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
 }
 
 type Usecase struct {
-	cfg  *config.ConfigModel
-	log  *zap.Logger
-	repo repository
+	cfg        *config.ConfigModel
+	log        *zap.Logger
+	repo       repository
+	metrics    *metrics.Metrics
+	jwt        *auth.JWTVerifier
+	strategies map[string]AssignmentStrategy
 }
 
 func NewUsecase(
 	log *zap.Logger,
 	repo *postgres.Repository,
 	cfg *config.ConfigModel,
+	m *metrics.Metrics,
+	jwt *auth.JWTVerifier,
 ) (*Usecase, error) {
-	return &Usecase{cfg: cfg, log: log, repo: repo}, nil
+	return &Usecase{
+		cfg:        cfg,
+		log:        log,
+		repo:       repo,
+		metrics:    m,
+		jwt:        jwt,
+		strategies: newStrategies(repo),
+	}, nil
 }
 
-func pickReviewers(users []entities.User, limit int) []string {
+func pickReviewers(users []entities.User, load map[string]int, limit int) []string {
 	if limit <= 0 || len(users) == 0 {
 		return nil
 	}
 
-	if len(users) <= limit {
-		res := make([]string, 0, len(users))
-		for _, u := range users {
-			res = append(res, u.UserID)
-		}
-		return res
-	}
-
 	idxs := make([]int, len(users))
 	for i := range users {
 		idxs[i] = i
@@ -65,11 +142,43 @@ func pickReviewers(users []entities.User, limit int) []string {
 	rand.Shuffle(len(idxs), func(i, j int) {
 		idxs[i], idxs[j] = idxs[j], idxs[i]
 	})
+	sort.SliceStable(idxs, func(i, j int) bool {
+		return load[users[idxs[i]].UserID] < load[users[idxs[j]].UserID]
+	})
 
-	res := make([]string, 0, limit)
-	for i := 0; i < limit; i++ {
-		res = append(res, users[idxs[i]].UserID)
+	if len(idxs) > limit {
+		idxs = idxs[:limit]
 	}
 
+	res := make([]string, 0, len(idxs))
+	for _, i := range idxs {
+		res = append(res, users[i].UserID)
+	}
+
+	return res
+}
+
+func userIDs(users []entities.User) []string {
+	res := make([]string, 0, len(users))
+	for _, u := range users {
+		res = append(res, u.UserID)
+	}
+	return res
+}
+
+func excludeUsers(users []entities.User, exclude ...[]string) []entities.User {
+	skip := make(map[string]struct{})
+	for _, ids := range exclude {
+		for _, id := range ids {
+			skip[id] = struct{}{}
+		}
+	}
+
+	res := make([]entities.User, 0, len(users))
+	for _, u := range users {
+		if _, ok := skip[u.UserID]; !ok {
+			res = append(res, u)
+		}
+	}
 	return res
 }
@@ -10,6 +10,13 @@ import (
 )
 
 func (u *Usecase) SetUserIsActive(ctx context.Context, userID string, isActive bool) (entities.User, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.SetUserIsActive")
+	defer span.End()
+
+	if err := u.authorizeUser(ctx, userID, false); err != nil {
+		return entities.User{}, err
+	}
+
 	user, err := u.repo.SetUserIsActive(ctx, userID, isActive)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -23,3 +30,54 @@ func (u *Usecase) SetUserIsActive(ctx context.Context, userID string, isActive b
 	}
 	return user, nil
 }
+
+func (u *Usecase) SetUserCapacity(ctx context.Context, req entities.SetCapacityRequest) (entities.ReviewCapacity, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.SetUserCapacity")
+	defer span.End()
+
+	if err := u.authorizeUser(ctx, req.UserID, false); err != nil {
+		return entities.ReviewCapacity{}, err
+	}
+
+	if err := u.repo.SetUserMaxOpenReviews(ctx, req.UserID, req.MaxOpenReviews); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.ReviewCapacity{}, &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "resource not found",
+			}
+		}
+		u.log.Error("failed to set user capacity", zap.Error(err))
+		return entities.ReviewCapacity{}, err
+	}
+
+	capacity, err := u.repo.GetReviewCapacity(ctx, req.UserID)
+	if err != nil {
+		u.log.Error("failed to get review capacity", zap.Error(err))
+		return entities.ReviewCapacity{}, err
+	}
+	return capacity, nil
+}
+
+func (u *Usecase) SetUserRole(ctx context.Context, req entities.SetRoleRequest) (entities.UserRole, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.SetUserRole")
+	defer span.End()
+
+	if err := u.authorizeAdmin(ctx); err != nil {
+		return entities.UserRole{}, err
+	}
+
+	ur, err := u.repo.SetUserRole(ctx, req.UserID, req.Role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.UserRole{}, &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "resource not found",
+			}
+		}
+		u.log.Error("failed to set user role", zap.Error(err))
+		return entities.UserRole{}, err
+	}
+
+	u.log.Info("user role changed", zap.String("user_id", ur.UserID), zap.String("role", ur.Role))
+	return ur, nil
+}
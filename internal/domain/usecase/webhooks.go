@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"pr-service/internal/domain/entities"
+
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+)
+
+func (u *Usecase) CreateWebhook(ctx context.Context, req entities.CreateWebhookRequest) (entities.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.CreateWebhook")
+	defer span.End()
+
+	if err := u.authorizeAdmin(ctx); err != nil {
+		return entities.Webhook{}, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			u.log.Error("failed to generate webhook secret", zap.Error(err))
+			return entities.Webhook{}, err
+		}
+		secret = hex.EncodeToString(buf)
+	}
+
+	wh, err := u.repo.CreateWebhook(ctx, entities.Webhook{
+		URL:        req.URL,
+		Secret:     secret,
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		u.log.Error("failed to create webhook", zap.Error(err))
+		return entities.Webhook{}, err
+	}
+	return wh, nil
+}
+
+func (u *Usecase) ListWebhooks(ctx context.Context) ([]entities.Webhook, error) {
+	ctx, span := tracer.Start(ctx, "Usecase.ListWebhooks")
+	defer span.End()
+
+	if err := u.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := u.repo.ListWebhooks(ctx)
+	if err != nil {
+		u.log.Error("failed to list webhooks", zap.Error(err))
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (u *Usecase) DeleteWebhook(ctx context.Context, id int64) error {
+	ctx, span := tracer.Start(ctx, "Usecase.DeleteWebhook")
+	defer span.End()
+
+	if err := u.authorizeAdmin(ctx); err != nil {
+		return err
+	}
+
+	if err := u.repo.DeleteWebhook(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &entities.DomainError{
+				Code:    entities.ErrorCodeNotFound,
+				Message: "resource not found",
+			}
+		}
+		u.log.Error("failed to delete webhook", zap.Error(err))
+		return err
+	}
+	return nil
+}
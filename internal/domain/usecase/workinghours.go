@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pr-service/internal/domain/entities"
+	"time"
+)
+
+// pick runs the team's strategy and records round_robin picks in rot.
+func (u *Usecase) pick(
+	ctx context.Context,
+	settings entities.TeamSettings,
+	teamName string,
+	candidates []entities.User,
+	limit int,
+	rot *rotations,
+) ([]string, error) {
+	strategy := u.strategy(settings.AssignmentStrategy)
+	if rr, ok := strategy.(roundRobinStrategy); ok {
+		rr.rot = rot
+		strategy = rr
+	}
+	return pickByWorkingHours(ctx, strategy, settings, teamName, candidates, limit)
+}
+
+// pickByWorkingHours with PreferWorkingHours first picks among candidates
+// currently inside their working hours and only then among the rest.
+func pickByWorkingHours(
+	ctx context.Context,
+	strategy AssignmentStrategy,
+	settings entities.TeamSettings,
+	teamName string,
+	candidates []entities.User,
+	limit int,
+) ([]string, error) {
+	if !settings.PreferWorkingHours {
+		return strategy.Pick(ctx, teamName, candidates, limit)
+	}
+
+	var inHours, offHours []entities.User
+	now := time.Now()
+	for _, c := range candidates {
+		if inWorkingHours(c, now) {
+			inHours = append(inHours, c)
+		} else {
+			offHours = append(offHours, c)
+		}
+	}
+
+	res, err := strategy.Pick(ctx, teamName, inHours, limit)
+	if err != nil {
+		return nil, err
+	}
+	if rest := limit - len(res); rest > 0 {
+		more, err := strategy.Pick(ctx, teamName, offHours, rest)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, more...)
+	}
+	return res, nil
+}
+
+// inWorkingHours reports whether now falls into the user's working hours in
+// their timezone. Shifts crossing midnight (e.g. 22:00-06:00) are supported;
+// unparsable settings count as always working so the user is not starved.
+func inWorkingHours(user entities.User, now time.Time) bool {
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		return true
+	}
+	start, err := parseClock(user.WorkStart)
+	if err != nil {
+		return true
+	}
+	end, err := parseClock(user.WorkEnd)
+	if err != nil {
+		return true
+	}
+
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	if start <= end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// parseClock parses "HH:MM" into minutes since midnight.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func normalizeWorkingHours(m *entities.TeamMember) error {
+	if m.Timezone == "" {
+		m.Timezone = entities.DefaultTimezone
+	}
+	if m.WorkStart == "" {
+		m.WorkStart = entities.DefaultWorkStart
+	}
+	if m.WorkEnd == "" {
+		m.WorkEnd = entities.DefaultWorkEnd
+	}
+
+	if _, err := time.LoadLocation(m.Timezone); err != nil {
+		return fmt.Errorf("user %s: unknown timezone %q", m.UserID, m.Timezone)
+	}
+	start, err := parseClock(m.WorkStart)
+	if err != nil {
+		return fmt.Errorf("user %s: %w", m.UserID, err)
+	}
+	end, err := parseClock(m.WorkEnd)
+	if err != nil {
+		return fmt.Errorf("user %s: %w", m.UserID, err)
+	}
+	if start == end {
+		return fmt.Errorf("user %s: work_start and work_end must differ", m.UserID)
+	}
+	return nil
+}
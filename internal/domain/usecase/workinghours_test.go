@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"pr-service/internal/domain/entities"
+)
+
+func TestInWorkingHours(t *testing.T) {
+	// 07:30 UTC is 10:30 in Moscow, 09:30 in Berlin (CEST) and 12:30 in Almaty.
+	now := time.Date(2025, time.June, 2, 7, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		name string
+		user entities.User
+		want bool
+	}{
+		{"moscow", entities.User{Timezone: "Europe/Moscow", WorkStart: "10:00", WorkEnd: "19:00"}, true},
+		{"berlin before start", entities.User{Timezone: "Europe/Berlin", WorkStart: "10:00", WorkEnd: "18:00"}, false},
+		{"almaty", entities.User{Timezone: "Asia/Almaty", WorkStart: "09:00", WorkEnd: "12:30"}, false},
+		{"overnight shift", entities.User{Timezone: "UTC", WorkStart: "22:00", WorkEnd: "08:00"}, true},
+		{"overnight shift off", entities.User{Timezone: "UTC", WorkStart: "22:00", WorkEnd: "07:00"}, false},
+		{"unknown timezone", entities.User{Timezone: "Mars/Olympus", WorkStart: "09:00", WorkEnd: "18:00"}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := inWorkingHours(tc.user, now); got != tc.want {
+				t.Fatalf("inWorkingHours = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeWorkingHours(t *testing.T) {
+	m := entities.TeamMember{UserID: "u1"}
+	if err := normalizeWorkingHours(&m); err != nil {
+		t.Fatalf("normalizeWorkingHours(defaults): %v", err)
+	}
+	if m.Timezone != entities.DefaultTimezone || m.WorkStart != entities.DefaultWorkStart || m.WorkEnd != entities.DefaultWorkEnd {
+		t.Fatalf("expected defaults, got %+v", m)
+	}
+
+	for _, bad := range []entities.TeamMember{
+		{UserID: "u2", Timezone: "Nowhere/City"},
+		{UserID: "u3", WorkStart: "9am"},
+		{UserID: "u4", WorkStart: "10:00", WorkEnd: "10:00"},
+	} {
+		if err := normalizeWorkingHours(&bad); err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package worker
+
+import (
+	"context"
+	"pr-service/config"
+	"pr-service/internal/domain/usecase"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type unavailableReassigner interface {
+	ReassignUnavailableReviewers(ctx context.Context) (int, error)
+}
+
+// AvailabilityJob hands over open reviews once a user's absence begins.
+type AvailabilityJob struct {
+	*periodic
+}
+
+func NewAvailabilityJob(log *zap.Logger, cfg *config.ConfigModel, uc *usecase.Usecase) *AvailabilityJob {
+	return newAvailabilityJob(log, cfg.Availability.CheckInterval, uc)
+}
+
+func newAvailabilityJob(log *zap.Logger, interval time.Duration, uc unavailableReassigner) *AvailabilityJob {
+	return &AvailabilityJob{
+		periodic: newPeriodic(log, "availability", interval, func(ctx context.Context) error {
+			_, err := uc.ReassignUnavailableReviewers(ctx)
+			return err
+		}),
+	}
+}
@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type fakeReassigner struct {
+	calls atomic.Int32
+}
+
+func (f *fakeReassigner) ReassignUnavailableReviewers(context.Context) (int, error) {
+	f.calls.Add(1)
+	return 0, nil
+}
+
+func TestAvailabilityJobRunsUntilStopped(t *testing.T) {
+	uc := &fakeReassigner{}
+	job := newAvailabilityJob(zap.NewNop(), 5*time.Millisecond, uc)
+
+	if err := job.OnStart(context.Background()); err != nil {
+		t.Fatalf("OnStart: %v", err)
+	}
+	if !job.Running() {
+		t.Fatalf("expected job to be running after start")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for uc.calls.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if uc.calls.Load() < 2 {
+		t.Fatalf("expected at least 2 runs, got %d", uc.calls.Load())
+	}
+
+	if err := job.OnStop(context.Background()); err != nil {
+		t.Fatalf("OnStop: %v", err)
+	}
+	if job.Running() {
+		t.Fatalf("expected job to stop")
+	}
+}
@@ -0,0 +1,186 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"pr-service/config"
+	"pr-service/internal/domain/entities"
+	"pr-service/internal/domain/repository/postgres"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	SignatureHeader = "X-PR-Service-Signature"
+	EventHeader     = "X-PR-Service-Event"
+	DeliveryHeader  = "X-PR-Service-Delivery"
+)
+
+type outboxRepository interface {
+	ClaimOutboxBatch(ctx context.Context, limit int, lease time.Duration) ([]entities.OutboxMessage, error)
+	MarkOutboxDelivered(ctx context.Context, id int64) error
+	MarkOutboxFailed(ctx context.Context, id int64, nextAttemptAt time.Time, lastErr string, dead bool) error
+}
+
+type Dispatcher struct {
+	log    *zap.Logger
+	cfg    config.WebhooksConfig
+	repo   outboxRepository
+	client *http.Client
+
+	cancel  context.CancelFunc
+	done    chan struct{}
+	running atomic.Bool
+}
+
+func NewDispatcher(log *zap.Logger, cfg *config.ConfigModel, repo *postgres.Repository) *Dispatcher {
+	return newDispatcher(log, cfg.Webhooks, repo)
+}
+
+func newDispatcher(log *zap.Logger, cfg config.WebhooksConfig, repo outboxRepository) *Dispatcher {
+	return &Dispatcher{
+		log:    log.Named("dispatcher"),
+		cfg:    cfg,
+		repo:   repo,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (d *Dispatcher) OnStart(_ context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.done = make(chan struct{})
+	d.running.Store(true)
+
+	go d.run(ctx)
+	d.log.Info("webhook dispatcher started", zap.Duration("interval", d.cfg.DispatchInterval))
+	return nil
+}
+
+func (d *Dispatcher) OnStop(ctx context.Context) error {
+	if d.cancel == nil {
+		return nil
+	}
+	d.cancel()
+
+	select {
+	case <-d.done:
+		d.log.Info("webhook dispatcher stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *Dispatcher) Running() bool {
+	return d.running.Load()
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	defer close(d.done)
+	defer d.running.Store(false)
+
+	ticker := time.NewTicker(d.cfg.DispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.dispatchBatch(ctx)
+		}
+	}
+}
+
+func (d *Dispatcher) dispatchBatch(ctx context.Context) {
+	lease := time.Duration(d.cfg.BatchSize) * d.cfg.Timeout
+	msgs, err := d.repo.ClaimOutboxBatch(ctx, d.cfg.BatchSize, lease)
+	if err != nil {
+		if ctx.Err() == nil {
+			d.log.Error("failed to claim outbox batch", zap.Error(err))
+		}
+		return
+	}
+
+	for _, m := range msgs {
+		d.deliver(ctx, m)
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, m entities.OutboxMessage) {
+	err := d.post(ctx, m)
+	if err == nil {
+		if err := d.repo.MarkOutboxDelivered(ctx, m.ID); err != nil {
+			d.log.Error("failed to mark outbox message delivered", zap.Int64("id", m.ID), zap.Error(err))
+		}
+		return
+	}
+
+	attempts := m.Attempts + 1
+	dead := attempts >= d.cfg.MaxAttempts
+	next := time.Now().Add(backoff(d.cfg.BackoffBase, d.cfg.BackoffMax, attempts))
+
+	log := d.log.With(
+		zap.Int64("id", m.ID),
+		zap.Int64("webhook_id", m.WebhookID),
+		zap.Int("attempts", attempts),
+		zap.Error(err),
+	)
+	if dead {
+		log.Error("webhook delivery moved to dead letter")
+	} else {
+		log.Warn("webhook delivery failed, will retry", zap.Time("next_attempt_at", next))
+	}
+
+	if err := d.repo.MarkOutboxFailed(ctx, m.ID, next, err.Error(), dead); err != nil {
+		d.log.Error("failed to mark outbox message failed", zap.Int64("id", m.ID), zap.Error(err))
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, m entities.OutboxMessage) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.URL, bytes.NewReader(m.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, m.EventType)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(m.ID, 10))
+	req.Header.Set(SignatureHeader, Sign(m.Secret, m.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func backoff(base, limit time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < limit; i++ {
+		delay *= 2
+	}
+	if delay > limit {
+		delay = limit
+	}
+	return delay
+}
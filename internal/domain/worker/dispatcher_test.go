@@ -0,0 +1,120 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"pr-service/config"
+	"pr-service/internal/domain/entities"
+
+	"go.uber.org/zap"
+)
+
+type fakeOutbox struct {
+	mu        sync.Mutex
+	delivered []int64
+	failed    map[int64]bool
+}
+
+func (f *fakeOutbox) ClaimOutboxBatch(context.Context, int, time.Duration) ([]entities.OutboxMessage, error) {
+	return nil, nil
+}
+
+func (f *fakeOutbox) MarkOutboxDelivered(_ context.Context, id int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.delivered = append(f.delivered, id)
+	return nil
+}
+
+func (f *fakeOutbox) MarkOutboxFailed(_ context.Context, id int64, _ time.Time, _ string, dead bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failed == nil {
+		f.failed = make(map[int64]bool)
+	}
+	f.failed[id] = dead
+	return nil
+}
+
+func TestDispatcherDeliverSignsPayload(t *testing.T) {
+	payload := []byte(`{"event":"reviewer.assigned"}`)
+
+	var gotSignature, gotEvent string
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(SignatureHeader)
+		gotEvent = r.Header.Get(EventHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	repo := &fakeOutbox{}
+	d := newDispatcher(zap.NewNop(), config.WebhooksConfig{MaxAttempts: 3, Timeout: time.Second}, repo)
+
+	d.deliver(context.Background(), entities.OutboxMessage{
+		ID:        1,
+		URL:       srv.URL,
+		Secret:    "s3cret",
+		EventType: entities.WebhookEventReviewerAssigned,
+		Payload:   payload,
+	})
+
+	if len(repo.delivered) != 1 || repo.delivered[0] != 1 {
+		t.Fatalf("expected message 1 to be marked delivered, got %v", repo.delivered)
+	}
+	if gotSignature != Sign("s3cret", payload) {
+		t.Fatalf("unexpected signature %q", gotSignature)
+	}
+	if gotEvent != entities.WebhookEventReviewerAssigned || string(gotBody) != string(payload) {
+		t.Fatalf("unexpected request: event=%q body=%s", gotEvent, gotBody)
+	}
+}
+
+func TestDispatcherDeliverDeadLettersAfterMaxAttempts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	repo := &fakeOutbox{}
+	d := newDispatcher(zap.NewNop(), config.WebhooksConfig{
+		MaxAttempts: 3,
+		BackoffBase: time.Second,
+		BackoffMax:  time.Minute,
+		Timeout:     time.Second,
+	}, repo)
+
+	d.deliver(context.Background(), entities.OutboxMessage{ID: 1, URL: srv.URL, Attempts: 0})
+	d.deliver(context.Background(), entities.OutboxMessage{ID: 2, URL: srv.URL, Attempts: 2})
+
+	if dead, ok := repo.failed[1]; !ok || dead {
+		t.Fatalf("expected message 1 to be retried, got failed=%v", repo.failed)
+	}
+	if dead, ok := repo.failed[2]; !ok || !dead {
+		t.Fatalf("expected message 2 to be dead-lettered, got failed=%v", repo.failed)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	cases := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{10, 30 * time.Second},
+	}
+	for _, tc := range cases {
+		if got := backoff(time.Second, 30*time.Second, tc.attempts); got != tc.want {
+			t.Fatalf("backoff(%d) = %v, want %v", tc.attempts, got, tc.want)
+		}
+	}
+}
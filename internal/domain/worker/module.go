@@ -0,0 +1,39 @@
+package worker
+
+import (
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+func New() fx.Option {
+	return fx.Module("worker",
+		fx.Provide(
+			NewDispatcher,
+			NewAvailabilityJob,
+			NewSLAScheduler,
+		),
+		fx.Invoke(
+			func(lc fx.Lifecycle, d *Dispatcher) {
+				lc.Append(fx.Hook{
+					OnStart: d.OnStart,
+					OnStop:  d.OnStop,
+				})
+			},
+			func(lc fx.Lifecycle, j *AvailabilityJob) {
+				lc.Append(fx.Hook{
+					OnStart: j.OnStart,
+					OnStop:  j.OnStop,
+				})
+			},
+			func(lc fx.Lifecycle, s *SLAScheduler) {
+				lc.Append(fx.Hook{
+					OnStart: s.OnStart,
+					OnStop:  s.OnStop,
+				})
+			},
+		),
+		fx.Decorate(func(log *zap.Logger) *zap.Logger {
+			return log.Named("worker")
+		}),
+	)
+}
@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"context"
+	"pr-service/internal/domain/entities"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// periodic runs fn every interval until stopped. It backs the simple
+// background jobs; the webhook dispatcher keeps its own loop.
+type periodic struct {
+	log      *zap.Logger
+	name     string
+	interval time.Duration
+	fn       func(ctx context.Context) error
+
+	cancel  context.CancelFunc
+	done    chan struct{}
+	running atomic.Bool
+}
+
+func newPeriodic(log *zap.Logger, name string, interval time.Duration, fn func(ctx context.Context) error) *periodic {
+	return &periodic{
+		log:      log.Named(name),
+		name:     name,
+		interval: interval,
+		fn:       fn,
+	}
+}
+
+func (p *periodic) OnStart(_ context.Context) error {
+	ctx, cancel := context.WithCancel(entities.WithPrincipal(context.Background(), entities.SystemPrincipal))
+	p.cancel = cancel
+	p.done = make(chan struct{})
+	p.running.Store(true)
+
+	go p.run(ctx)
+	p.log.Info("job started", zap.Duration("interval", p.interval))
+	return nil
+}
+
+func (p *periodic) OnStop(ctx context.Context) error {
+	if p.cancel == nil {
+		return nil
+	}
+	p.cancel()
+
+	select {
+	case <-p.done:
+		p.log.Info("job stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *periodic) Running() bool {
+	return p.running.Load()
+}
+
+func (p *periodic) run(ctx context.Context) {
+	defer close(p.done)
+	defer p.running.Store(false)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.fn(ctx); err != nil && ctx.Err() == nil {
+				p.log.Error("job run failed", zap.Error(err))
+			}
+		}
+	}
+}
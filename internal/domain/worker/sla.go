@@ -0,0 +1,121 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"pr-service/config"
+	"pr-service/internal/domain/entities"
+	"pr-service/internal/domain/usecase"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type staleReviewHandler interface {
+	ListStaleReviews(ctx context.Context) ([]entities.StaleReview, error)
+	MarkReviewReminded(ctx context.Context, prID, reviewerID string) error
+	EscalateReview(ctx context.Context, sr entities.StaleReview) (string, error)
+}
+
+// SLAScheduler reminds reviewers who sit on a PR past the team's reminder
+// threshold and reassigns the review past the escalation threshold.
+type SLAScheduler struct {
+	*periodic
+
+	log    *zap.Logger
+	cfg    config.SLAConfig
+	uc     staleReviewHandler
+	client *http.Client
+}
+
+func NewSLAScheduler(log *zap.Logger, cfg *config.ConfigModel, uc *usecase.Usecase) *SLAScheduler {
+	return newSLAScheduler(log, cfg.SLA, uc)
+}
+
+func newSLAScheduler(log *zap.Logger, cfg config.SLAConfig, uc staleReviewHandler) *SLAScheduler {
+	s := &SLAScheduler{
+		log:    log.Named("sla"),
+		cfg:    cfg,
+		uc:     uc,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+	s.periodic = newPeriodic(log, "sla", cfg.CheckInterval, s.scan)
+	return s
+}
+
+func (s *SLAScheduler) scan(ctx context.Context) error {
+	reviews, err := s.uc.ListStaleReviews(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, sr := range reviews {
+		if ctx.Err() != nil {
+			return nil
+		}
+		// Stale reviews are listed across tenants.
+		rctx := entities.WithTenant(ctx, sr.TenantID)
+		if sr.Escalate {
+			// The outcome is logged by the usecase.
+			_, _ = s.uc.EscalateReview(rctx, sr)
+			continue
+		}
+		s.remind(rctx, sr)
+	}
+	return nil
+}
+
+func (s *SLAScheduler) remind(ctx context.Context, sr entities.StaleReview) {
+	log := s.log.With(
+		zap.String("pull_request_id", sr.PullRequestID),
+		zap.String("reviewer_id", sr.ReviewerID),
+	)
+
+	if s.cfg.ReminderURL == "" {
+		log.Info("review is past reminder threshold", zap.Time("assigned_at", sr.AssignedAt))
+	} else if err := s.post(ctx, sr); err != nil {
+		log.Warn("failed to send review reminder, will retry", zap.Error(err))
+		return
+	}
+
+	if err := s.uc.MarkReviewReminded(ctx, sr.PullRequestID, sr.ReviewerID); err != nil {
+		log.Error("failed to mark review reminded", zap.Error(err))
+	}
+}
+
+func (s *SLAScheduler) post(ctx context.Context, sr entities.StaleReview) error {
+	now := time.Now().UTC()
+	body, err := json.Marshal(entities.ReviewReminder{
+		Event:          entities.EventReviewReminder,
+		OccurredAt:     now,
+		WaitingMinutes: int(now.Sub(sr.AssignedAt).Minutes()),
+		Review:         sr,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.ReminderURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, entities.EventReviewReminder)
+	if s.cfg.ReminderSecret != "" {
+		req.Header.Set(SignatureHeader, Sign(s.cfg.ReminderSecret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
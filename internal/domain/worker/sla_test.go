@@ -0,0 +1,103 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"pr-service/config"
+	"pr-service/internal/domain/entities"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type fakeStaleReviews struct {
+	reviews   []entities.StaleReview
+	reminded  []string
+	escalated []string
+}
+
+func (f *fakeStaleReviews) ListStaleReviews(context.Context) ([]entities.StaleReview, error) {
+	return f.reviews, nil
+}
+
+func (f *fakeStaleReviews) MarkReviewReminded(_ context.Context, prID, reviewerID string) error {
+	f.reminded = append(f.reminded, prID+"/"+reviewerID)
+	return nil
+}
+
+func (f *fakeStaleReviews) EscalateReview(_ context.Context, sr entities.StaleReview) (string, error) {
+	f.escalated = append(f.escalated, sr.PullRequestID+"/"+sr.ReviewerID)
+	return "u9", nil
+}
+
+func TestSLASchedulerRemindsAndEscalates(t *testing.T) {
+	var got entities.ReviewReminder
+	var signature string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("decode reminder: %v", err)
+		}
+		if want := Sign("secret", body); signature != want {
+			t.Errorf("signature = %q, want %q", signature, want)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	uc := &fakeStaleReviews{reviews: []entities.StaleReview{
+		{PullRequestID: "pr-1", ReviewerID: "u1", AssignedAt: time.Now().Add(-90 * time.Minute)},
+		{PullRequestID: "pr-2", ReviewerID: "u2", AssignedAt: time.Now().Add(-5 * time.Hour), Escalate: true},
+	}}
+	s := newSLAScheduler(zap.NewNop(), config.SLAConfig{
+		CheckInterval:  time.Minute,
+		ReminderURL:    srv.URL,
+		ReminderSecret: "secret",
+		Timeout:        time.Second,
+	}, uc)
+
+	if err := s.scan(context.Background()); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+
+	if len(uc.reminded) != 1 || uc.reminded[0] != "pr-1/u1" {
+		t.Fatalf("reminded = %v, want [pr-1/u1]", uc.reminded)
+	}
+	if len(uc.escalated) != 1 || uc.escalated[0] != "pr-2/u2" {
+		t.Fatalf("escalated = %v, want [pr-2/u2]", uc.escalated)
+	}
+	if got.Event != entities.EventReviewReminder || got.Review.PullRequestID != "pr-1" {
+		t.Fatalf("unexpected reminder payload: %+v", got)
+	}
+	if got.WaitingMinutes < 89 {
+		t.Fatalf("waiting_minutes = %d, want >= 89", got.WaitingMinutes)
+	}
+}
+
+func TestSLASchedulerRetriesFailedReminder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	uc := &fakeStaleReviews{reviews: []entities.StaleReview{
+		{PullRequestID: "pr-1", ReviewerID: "u1", AssignedAt: time.Now().Add(-time.Hour)},
+	}}
+	s := newSLAScheduler(zap.NewNop(), config.SLAConfig{
+		CheckInterval: time.Minute,
+		ReminderURL:   srv.URL,
+		Timeout:       time.Second,
+	}, uc)
+
+	if err := s.scan(context.Background()); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if len(uc.reminded) != 0 {
+		t.Fatalf("failed reminder must not be marked as sent, got %v", uc.reminded)
+	}
+}
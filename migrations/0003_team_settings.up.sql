@@ -0,0 +1,7 @@
+CREATE TABLE team_settings (
+                               team_name           TEXT PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE,
+                               assignment_strategy TEXT NOT NULL DEFAULT 'least_loaded'
+                                   CHECK (assignment_strategy IN ('random', 'round_robin', 'least_loaded', 'weighted')),
+                               reviewers_count     INT NOT NULL DEFAULT 2 CHECK (reviewers_count BETWEEN 1 AND 10),
+                               updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,5 @@
+CREATE TABLE team_rotation_cursors (
+                                       team_name    TEXT PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE,
+                                       last_user_id TEXT NOT NULL DEFAULT '',
+                                       updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_reviewers_reviewer_state;
+
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN IF EXISTS state_updated_at,
+    DROP COLUMN IF EXISTS state;
+
+DROP TYPE IF EXISTS review_state;
@@ -0,0 +1,7 @@
+CREATE TYPE review_state AS ENUM ('PENDING', 'APPROVED', 'CHANGES_REQUESTED', 'COMMENTED');
+
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN state            review_state NOT NULL DEFAULT 'PENDING',
+    ADD COLUMN state_updated_at TIMESTAMPTZ NULL;
+
+CREATE INDEX idx_reviewers_reviewer_state ON pull_request_reviewers(reviewer_id, state);
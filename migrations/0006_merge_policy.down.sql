@@ -0,0 +1,3 @@
+ALTER TABLE team_settings
+    DROP COLUMN IF EXISTS require_all_approved,
+    DROP COLUMN IF EXISTS min_approvals;
@@ -0,0 +1,3 @@
+ALTER TABLE team_settings
+    ADD COLUMN min_approvals        INT NOT NULL DEFAULT 0 CHECK (min_approvals >= 0),
+    ADD COLUMN require_all_approved BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,11 @@
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS closed_at;
+
+DROP INDEX IF EXISTS idx_pull_requests_status;
+ALTER TABLE pull_requests ALTER COLUMN status TYPE TEXT;
+UPDATE pull_requests SET status = 'OPEN' WHERE status IN ('DRAFT', 'CLOSED');
+
+DROP TYPE pr_status;
+CREATE TYPE pr_status AS ENUM ('OPEN', 'MERGED');
+
+ALTER TABLE pull_requests ALTER COLUMN status TYPE pr_status USING status::pr_status;
+CREATE INDEX idx_pull_requests_status ON pull_requests(status);
@@ -0,0 +1,24 @@
+CREATE TABLE assignment_events (
+                                   id              BIGSERIAL PRIMARY KEY,
+                                   pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE RESTRICT,
+                                   event_type      TEXT NOT NULL
+                                       CHECK (event_type IN ('ASSIGN', 'REASSIGN', 'UNASSIGN', 'MERGE', 'DEACTIVATE', 'CLOSE', 'REOPEN')),
+                                   old_reviewer_id TEXT NULL,
+                                   new_reviewer_id TEXT NULL,
+                                   reason          TEXT NOT NULL DEFAULT '',
+                                   actor           TEXT NOT NULL,
+                                   created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_assignment_events_pr ON assignment_events(pull_request_id, id);
+
+-- журнал только на добавление
+CREATE FUNCTION assignment_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'assignment_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_assignment_events_append_only
+    BEFORE UPDATE OR DELETE ON assignment_events
+    FOR EACH ROW EXECUTE FUNCTION assignment_events_append_only();
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS webhook_outbox;
+DROP TYPE IF EXISTS outbox_status;
+DROP TABLE IF EXISTS webhooks;
@@ -0,0 +1,26 @@
+CREATE TABLE webhooks (
+                          id          BIGSERIAL PRIMARY KEY,
+                          url         TEXT NOT NULL,
+                          secret      TEXT NOT NULL,
+                          event_types TEXT[] NOT NULL DEFAULT '{}',
+                          is_active   BOOLEAN NOT NULL DEFAULT TRUE,
+                          created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TYPE outbox_status AS ENUM ('PENDING', 'DELIVERED', 'DEAD');
+
+CREATE TABLE webhook_outbox (
+                                id              BIGSERIAL PRIMARY KEY,
+                                webhook_id      BIGINT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+                                event_type      TEXT NOT NULL,
+                                payload         JSONB NOT NULL,
+                                status          outbox_status NOT NULL DEFAULT 'PENDING',
+                                attempts        INT NOT NULL DEFAULT 0,
+                                next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                last_error      TEXT NOT NULL DEFAULT '',
+                                created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                delivered_at    TIMESTAMPTZ NULL
+);
+
+CREATE INDEX idx_webhook_outbox_pending ON webhook_outbox(next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX idx_webhook_outbox_webhook ON webhook_outbox(webhook_id, status);
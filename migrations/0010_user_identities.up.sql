@@ -0,0 +1,9 @@
+CREATE TABLE user_identities (
+                                 provider       TEXT NOT NULL,
+                                 external_login TEXT NOT NULL,
+                                 user_id        TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+                                 created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                 PRIMARY KEY (provider, external_login)
+);
+
+CREATE INDEX idx_user_identities_user ON user_identities(user_id);
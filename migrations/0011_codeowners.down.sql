@@ -0,0 +1,4 @@
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS changed_files;
+
+DROP TABLE IF EXISTS team_codeowners;
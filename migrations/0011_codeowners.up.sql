@@ -0,0 +1,8 @@
+CREATE TABLE team_codeowners (
+                                 team_name  TEXT PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE,
+                                 content    TEXT NOT NULL,
+                                 updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE pull_requests
+    ADD COLUMN changed_files TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+ALTER TABLE team_settings
+    ADD COLUMN fallback_teams TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,5 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS max_open_reviews;
+
+ALTER TABLE team_settings
+    DROP COLUMN IF EXISTS max_open_reviews;
@@ -0,0 +1,5 @@
+ALTER TABLE team_settings
+    ADD COLUMN max_open_reviews INT NOT NULL DEFAULT 0 CHECK (max_open_reviews >= 0);
+
+ALTER TABLE users
+    ADD COLUMN max_open_reviews INT NULL CHECK (max_open_reviews >= 0);
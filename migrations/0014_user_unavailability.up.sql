@@ -0,0 +1,13 @@
+CREATE TABLE user_unavailability (
+                                     id            BIGSERIAL PRIMARY KEY,
+                                     user_id       TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+                                     starts_at     TIMESTAMPTZ NOT NULL,
+                                     ends_at       TIMESTAMPTZ NOT NULL,
+                                     reason        TEXT NOT NULL DEFAULT '',
+                                     reassigned_at TIMESTAMPTZ NULL,
+                                     created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                                     CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_user_unavailability_user ON user_unavailability(user_id, starts_at, ends_at);
+CREATE INDEX idx_user_unavailability_pending ON user_unavailability(starts_at) WHERE reassigned_at IS NULL;
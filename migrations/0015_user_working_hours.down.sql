@@ -0,0 +1,7 @@
+ALTER TABLE team_settings
+    DROP COLUMN IF EXISTS prefer_working_hours;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS work_end,
+    DROP COLUMN IF EXISTS work_start,
+    DROP COLUMN IF EXISTS timezone;
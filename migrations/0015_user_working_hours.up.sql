@@ -0,0 +1,7 @@
+ALTER TABLE users
+    ADD COLUMN timezone   TEXT NOT NULL DEFAULT 'UTC',
+    ADD COLUMN work_start TEXT NOT NULL DEFAULT '09:00' CHECK (work_start ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$'),
+    ADD COLUMN work_end   TEXT NOT NULL DEFAULT '18:00' CHECK (work_end ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$');
+
+ALTER TABLE team_settings
+    ADD COLUMN prefer_working_hours BOOLEAN NOT NULL DEFAULT FALSE;
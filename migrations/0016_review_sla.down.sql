@@ -0,0 +1,10 @@
+ALTER TABLE team_settings
+    DROP COLUMN IF EXISTS escalate_after_minutes,
+    DROP COLUMN IF EXISTS reminder_after_minutes;
+
+DROP INDEX IF EXISTS idx_reviewers_pending_assigned;
+
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN IF EXISTS escalated_at,
+    DROP COLUMN IF EXISTS reminded_at,
+    DROP COLUMN IF EXISTS assigned_at;
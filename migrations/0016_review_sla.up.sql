@@ -0,0 +1,15 @@
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN assigned_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN reminded_at  TIMESTAMPTZ NULL,
+    ADD COLUMN escalated_at TIMESTAMPTZ NULL;
+
+UPDATE pull_request_reviewers rpr
+SET assigned_at = p.created_at
+FROM pull_requests p
+WHERE p.pull_request_id = rpr.pull_request_id;
+
+CREATE INDEX idx_reviewers_pending_assigned ON pull_request_reviewers(assigned_at) WHERE state = 'PENDING';
+
+ALTER TABLE team_settings
+    ADD COLUMN reminder_after_minutes INT NOT NULL DEFAULT 0 CHECK (reminder_after_minutes >= 0),
+    ADD COLUMN escalate_after_minutes INT NOT NULL DEFAULT 0 CHECK (escalate_after_minutes >= 0);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_pull_requests_created_at;
+
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN IF EXISTS approved_at,
+    DROP COLUMN IF EXISTS first_response_at;
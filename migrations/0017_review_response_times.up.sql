@@ -0,0 +1,11 @@
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN first_response_at TIMESTAMPTZ NULL,
+    ADD COLUMN approved_at       TIMESTAMPTZ NULL;
+
+-- Best effort for existing rows: the last state change is all that is known.
+UPDATE pull_request_reviewers
+SET first_response_at = state_updated_at,
+    approved_at = CASE WHEN state = 'APPROVED' THEN state_updated_at END
+WHERE state <> 'PENDING';
+
+CREATE INDEX idx_pull_requests_created_at ON pull_requests(created_at);
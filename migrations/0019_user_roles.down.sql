@@ -0,0 +1,7 @@
+ALTER TABLE api_keys
+    DROP COLUMN IF EXISTS user_id;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS role;
+
+DROP TYPE IF EXISTS user_role;
@@ -0,0 +1,7 @@
+CREATE TYPE user_role AS ENUM ('ADMIN', 'TEAM_LEAD', 'DEVELOPER', 'READ_ONLY');
+
+ALTER TABLE users
+    ADD COLUMN role user_role NOT NULL DEFAULT 'DEVELOPER';
+
+ALTER TABLE api_keys
+    ADD COLUMN user_id TEXT NULL REFERENCES users(user_id) ON DELETE CASCADE;
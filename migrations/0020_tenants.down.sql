@@ -0,0 +1,85 @@
+-- данные всех тенантов, кроме default, удаляются: без tenant_id их
+-- идентификаторы могут конфликтовать
+ALTER TABLE assignment_events DISABLE TRIGGER trg_assignment_events_append_only;
+DELETE FROM assignment_events      WHERE tenant_id <> 'default';
+ALTER TABLE assignment_events ENABLE TRIGGER trg_assignment_events_append_only;
+DELETE FROM api_keys               WHERE tenant_id <> 'default';
+DELETE FROM webhooks               WHERE tenant_id <> 'default';
+DELETE FROM user_unavailability    WHERE tenant_id <> 'default';
+DELETE FROM user_identities        WHERE tenant_id <> 'default';
+DELETE FROM pull_request_reviewers WHERE tenant_id <> 'default';
+DELETE FROM pull_requests          WHERE tenant_id <> 'default';
+DELETE FROM team_codeowners        WHERE tenant_id <> 'default';
+DELETE FROM team_rotation_cursors  WHERE tenant_id <> 'default';
+DELETE FROM team_settings          WHERE tenant_id <> 'default';
+DELETE FROM users                  WHERE tenant_id <> 'default';
+DELETE FROM teams                  WHERE tenant_id <> 'default';
+
+DROP INDEX IF EXISTS idx_api_keys_tenant;
+DROP INDEX IF EXISTS idx_webhooks_tenant;
+
+ALTER TABLE user_unavailability    DROP CONSTRAINT user_unavailability_tenant_id_user_id_fkey;
+ALTER TABLE user_identities        DROP CONSTRAINT user_identities_tenant_id_user_id_fkey;
+ALTER TABLE assignment_events      DROP CONSTRAINT assignment_events_tenant_id_pull_request_id_fkey;
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_tenant_id_pull_request_id_fkey;
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_tenant_id_reviewer_id_fkey;
+ALTER TABLE pull_requests          DROP CONSTRAINT pull_requests_tenant_id_author_id_fkey;
+ALTER TABLE team_codeowners        DROP CONSTRAINT team_codeowners_tenant_id_team_name_fkey;
+ALTER TABLE team_rotation_cursors  DROP CONSTRAINT team_rotation_cursors_tenant_id_team_name_fkey;
+ALTER TABLE team_settings          DROP CONSTRAINT team_settings_tenant_id_team_name_fkey;
+ALTER TABLE users                  DROP CONSTRAINT users_tenant_id_team_name_fkey;
+ALTER TABLE api_keys               DROP CONSTRAINT api_keys_tenant_id_user_id_fkey;
+
+ALTER TABLE teams                  DROP CONSTRAINT teams_pkey,                  ADD PRIMARY KEY (team_name);
+ALTER TABLE users                  DROP CONSTRAINT users_pkey,                  ADD PRIMARY KEY (user_id);
+ALTER TABLE pull_requests          DROP CONSTRAINT pull_requests_pkey,          ADD PRIMARY KEY (pull_request_id);
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_pkey, ADD PRIMARY KEY (pull_request_id, reviewer_id);
+ALTER TABLE team_settings          DROP CONSTRAINT team_settings_pkey,          ADD PRIMARY KEY (team_name);
+ALTER TABLE team_rotation_cursors  DROP CONSTRAINT team_rotation_cursors_pkey,  ADD PRIMARY KEY (team_name);
+ALTER TABLE team_codeowners        DROP CONSTRAINT team_codeowners_pkey,        ADD PRIMARY KEY (team_name);
+ALTER TABLE user_identities        DROP CONSTRAINT user_identities_pkey,        ADD PRIMARY KEY (provider, external_login);
+
+ALTER TABLE users                  ADD FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE RESTRICT;
+ALTER TABLE team_settings          ADD FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE;
+ALTER TABLE team_rotation_cursors  ADD FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE;
+ALTER TABLE team_codeowners        ADD FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE;
+ALTER TABLE pull_requests          ADD FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE RESTRICT;
+ALTER TABLE pull_request_reviewers ADD FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE;
+ALTER TABLE pull_request_reviewers ADD FOREIGN KEY (reviewer_id) REFERENCES users(user_id) ON DELETE RESTRICT;
+ALTER TABLE assignment_events      ADD FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE RESTRICT;
+ALTER TABLE user_identities        ADD FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE;
+ALTER TABLE user_unavailability    ADD FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE;
+ALTER TABLE api_keys               ADD FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE;
+
+DROP INDEX idx_users_team_name;
+DROP INDEX idx_users_team_active;
+DROP INDEX idx_pull_requests_author;
+DROP INDEX idx_reviewers_reviewer;
+DROP INDEX idx_reviewers_reviewer_state;
+DROP INDEX idx_assignment_events_pr;
+DROP INDEX idx_user_identities_user;
+DROP INDEX idx_user_unavailability_user;
+
+ALTER TABLE teams                  DROP COLUMN tenant_id;
+ALTER TABLE users                  DROP COLUMN tenant_id;
+ALTER TABLE pull_requests          DROP COLUMN tenant_id;
+ALTER TABLE pull_request_reviewers DROP COLUMN tenant_id;
+ALTER TABLE team_settings          DROP COLUMN tenant_id;
+ALTER TABLE team_rotation_cursors  DROP COLUMN tenant_id;
+ALTER TABLE team_codeowners        DROP COLUMN tenant_id;
+ALTER TABLE assignment_events      DROP COLUMN tenant_id;
+ALTER TABLE user_identities        DROP COLUMN tenant_id;
+ALTER TABLE user_unavailability    DROP COLUMN tenant_id;
+ALTER TABLE webhooks               DROP COLUMN tenant_id;
+ALTER TABLE api_keys               DROP COLUMN tenant_id;
+
+CREATE INDEX idx_users_team_name ON users(team_name);
+CREATE INDEX idx_users_team_active ON users(team_name, is_active);
+CREATE INDEX idx_pull_requests_author ON pull_requests(author_id);
+CREATE INDEX idx_reviewers_reviewer ON pull_request_reviewers(reviewer_id);
+CREATE INDEX idx_reviewers_reviewer_state ON pull_request_reviewers(reviewer_id, state);
+CREATE INDEX idx_assignment_events_pr ON assignment_events(pull_request_id, id);
+CREATE INDEX idx_user_identities_user ON user_identities(user_id);
+CREATE INDEX idx_user_unavailability_user ON user_unavailability(user_id, starts_at, ends_at);
+
+DROP TABLE IF EXISTS tenants;
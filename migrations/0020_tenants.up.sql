@@ -0,0 +1,104 @@
+CREATE TABLE tenants (
+    tenant_id  TEXT PRIMARY KEY,
+    name       TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO tenants (tenant_id, name) VALUES ('default', 'Default');
+
+-- существующие данные переносятся в тенант default
+ALTER TABLE teams                  ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE users                  ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE pull_requests          ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE pull_request_reviewers ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE team_settings          ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE team_rotation_cursors  ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE team_codeowners        ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE assignment_events      ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE user_identities        ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE user_unavailability    ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE webhooks               ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE api_keys               ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+
+-- новые строки должны явно указывать тенант
+ALTER TABLE teams                  ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE users                  ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE pull_requests          ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE pull_request_reviewers ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE team_settings          ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE team_rotation_cursors  ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE team_codeowners        ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE assignment_events      ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE user_identities        ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE user_unavailability    ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE webhooks               ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE api_keys               ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_pull_request_id_fkey;
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_reviewer_id_fkey;
+ALTER TABLE assignment_events      DROP CONSTRAINT assignment_events_pull_request_id_fkey;
+ALTER TABLE user_identities        DROP CONSTRAINT user_identities_user_id_fkey;
+ALTER TABLE user_unavailability    DROP CONSTRAINT user_unavailability_user_id_fkey;
+ALTER TABLE api_keys               DROP CONSTRAINT api_keys_user_id_fkey;
+ALTER TABLE pull_requests          DROP CONSTRAINT pull_requests_author_id_fkey;
+ALTER TABLE users                  DROP CONSTRAINT users_team_name_fkey;
+ALTER TABLE team_settings          DROP CONSTRAINT team_settings_team_name_fkey;
+ALTER TABLE team_rotation_cursors  DROP CONSTRAINT team_rotation_cursors_team_name_fkey;
+ALTER TABLE team_codeowners        DROP CONSTRAINT team_codeowners_team_name_fkey;
+
+-- идентификаторы уникальны в пределах тенанта
+ALTER TABLE teams                  DROP CONSTRAINT teams_pkey,                  ADD PRIMARY KEY (tenant_id, team_name);
+ALTER TABLE users                  DROP CONSTRAINT users_pkey,                  ADD PRIMARY KEY (tenant_id, user_id);
+ALTER TABLE pull_requests          DROP CONSTRAINT pull_requests_pkey,          ADD PRIMARY KEY (tenant_id, pull_request_id);
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_pkey, ADD PRIMARY KEY (tenant_id, pull_request_id, reviewer_id);
+ALTER TABLE team_settings          DROP CONSTRAINT team_settings_pkey,          ADD PRIMARY KEY (tenant_id, team_name);
+ALTER TABLE team_rotation_cursors  DROP CONSTRAINT team_rotation_cursors_pkey,  ADD PRIMARY KEY (tenant_id, team_name);
+ALTER TABLE team_codeowners        DROP CONSTRAINT team_codeowners_pkey,        ADD PRIMARY KEY (tenant_id, team_name);
+ALTER TABLE user_identities        DROP CONSTRAINT user_identities_pkey,        ADD PRIMARY KEY (tenant_id, provider, external_login);
+
+ALTER TABLE teams
+    ADD FOREIGN KEY (tenant_id) REFERENCES tenants(tenant_id) ON DELETE RESTRICT;
+ALTER TABLE webhooks
+    ADD FOREIGN KEY (tenant_id) REFERENCES tenants(tenant_id) ON DELETE CASCADE;
+ALTER TABLE api_keys
+    ADD FOREIGN KEY (tenant_id) REFERENCES tenants(tenant_id) ON DELETE CASCADE,
+    ADD FOREIGN KEY (tenant_id, user_id) REFERENCES users(tenant_id, user_id) ON DELETE CASCADE;
+ALTER TABLE users
+    ADD FOREIGN KEY (tenant_id, team_name) REFERENCES teams(tenant_id, team_name) ON DELETE RESTRICT;
+ALTER TABLE team_settings
+    ADD FOREIGN KEY (tenant_id, team_name) REFERENCES teams(tenant_id, team_name) ON DELETE CASCADE;
+ALTER TABLE team_rotation_cursors
+    ADD FOREIGN KEY (tenant_id, team_name) REFERENCES teams(tenant_id, team_name) ON DELETE CASCADE;
+ALTER TABLE team_codeowners
+    ADD FOREIGN KEY (tenant_id, team_name) REFERENCES teams(tenant_id, team_name) ON DELETE CASCADE;
+ALTER TABLE pull_requests
+    ADD FOREIGN KEY (tenant_id, author_id) REFERENCES users(tenant_id, user_id) ON DELETE RESTRICT;
+ALTER TABLE pull_request_reviewers
+    ADD FOREIGN KEY (tenant_id, pull_request_id) REFERENCES pull_requests(tenant_id, pull_request_id) ON DELETE CASCADE,
+    ADD FOREIGN KEY (tenant_id, reviewer_id) REFERENCES users(tenant_id, user_id) ON DELETE RESTRICT;
+ALTER TABLE assignment_events
+    ADD FOREIGN KEY (tenant_id, pull_request_id) REFERENCES pull_requests(tenant_id, pull_request_id) ON DELETE RESTRICT;
+ALTER TABLE user_identities
+    ADD FOREIGN KEY (tenant_id, user_id) REFERENCES users(tenant_id, user_id) ON DELETE CASCADE;
+ALTER TABLE user_unavailability
+    ADD FOREIGN KEY (tenant_id, user_id) REFERENCES users(tenant_id, user_id) ON DELETE CASCADE;
+
+DROP INDEX idx_users_team_name;
+DROP INDEX idx_users_team_active;
+DROP INDEX idx_pull_requests_author;
+DROP INDEX idx_reviewers_reviewer;
+DROP INDEX idx_reviewers_reviewer_state;
+DROP INDEX idx_assignment_events_pr;
+DROP INDEX idx_user_identities_user;
+DROP INDEX idx_user_unavailability_user;
+
+CREATE INDEX idx_users_team_name ON users(tenant_id, team_name);
+CREATE INDEX idx_users_team_active ON users(tenant_id, team_name, is_active);
+CREATE INDEX idx_pull_requests_author ON pull_requests(tenant_id, author_id);
+CREATE INDEX idx_reviewers_reviewer ON pull_request_reviewers(tenant_id, reviewer_id);
+CREATE INDEX idx_reviewers_reviewer_state ON pull_request_reviewers(tenant_id, reviewer_id, state);
+CREATE INDEX idx_assignment_events_pr ON assignment_events(tenant_id, pull_request_id, id);
+CREATE INDEX idx_user_identities_user ON user_identities(tenant_id, user_id);
+CREATE INDEX idx_user_unavailability_user ON user_unavailability(tenant_id, user_id, starts_at, ends_at);
+CREATE INDEX idx_webhooks_tenant ON webhooks(tenant_id);
+CREATE INDEX idx_api_keys_tenant ON api_keys(tenant_id);
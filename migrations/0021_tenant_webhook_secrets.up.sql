@@ -0,0 +1,9 @@
+-- секреты входящих вебхуков GitHub/GitLab; тенант запроса определяется
+-- по секрету, которым он подписан
+CREATE TABLE tenant_webhook_secrets (
+    tenant_id  TEXT NOT NULL REFERENCES tenants(tenant_id) ON DELETE CASCADE,
+    provider   TEXT NOT NULL,
+    secret     TEXT NOT NULL UNIQUE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, provider)
+);